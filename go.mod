@@ -2,8 +2,11 @@ module github.com/terraform-providers/terraform-provider-gridscale
 
 require (
 	github.com/aws/aws-sdk-go v1.44.114
+	github.com/go-sql-driver/mysql v1.6.0
 	github.com/gridscale/gsclient-go/v3 v3.10.1
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.16.0
+	github.com/lib/pq v1.10.7
 )
 
 require (
@@ -18,7 +21,6 @@ require (
 	github.com/hashicorp/errwrap v1.0.0 // indirect
 	github.com/hashicorp/go-checkpoint v0.5.0 // indirect
 	github.com/hashicorp/go-cleanhttp v0.5.2 // indirect
-	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320 // indirect
 	github.com/hashicorp/go-hclog v1.2.0 // indirect
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
 	github.com/hashicorp/go-plugin v1.4.3 // indirect
@@ -0,0 +1,60 @@
+package gridscale
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+// sendAPIRequest sends a request directly to the gridscale API, using the configuration
+// (API URL, credentials, HTTP client) of the gsclient.Client.
+// It is only used for API features which are not supported by gsclient-go yet.
+// The response body is decoded into output (if output is not nil).
+func sendAPIRequest(ctx context.Context, client *gsclient.Client, method, uri string, body, output interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, client.APIURL()+uri, &reqBody)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("User-Agent", client.UserAgent())
+	httpReq.Header.Set("Content-Type", "application/json")
+	if client.UserUUID() != "" {
+		httpReq.Header.Set("X-Auth-UserId", client.UserUUID())
+	}
+	if client.APIToken() != "" {
+		httpReq.Header.Set("X-Auth-Token", client.APIToken())
+	}
+	log.Printf("[DEBUG] Sending %s request to %s", method, uri)
+	httpResp, err := client.HttpClient().Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+	if httpResp.StatusCode >= 300 {
+		requestError := gsclient.RequestError{
+			StatusCode:  httpResp.StatusCode,
+			RequestUUID: httpResp.Header.Get("X-Request-Id"),
+		}
+		// The error body contains title and description of the error (if there are any)
+		_ = json.Unmarshal(respBody, &requestError)
+		return requestError
+	}
+	if output != nil {
+		return json.Unmarshal(respBody, output)
+	}
+	return nil
+}
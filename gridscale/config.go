@@ -22,6 +22,8 @@ var postgreSQLPerformanceClasses = []string{"standard", "high", "insane", "ultra
 var filesystemPerformanceClasses = []string{"standard", "high", "insane", "ultra"}
 var msSQLServerPerformanceClasses = []string{"standard", "high", "insane", "ultra"}
 var mariaDBPerformanceClasses = []string{"standard", "high", "insane", "ultra"}
+var usageQueryLevels = []string{"project", "contract"}
+var usageIntervalVariables = []string{gsclient.HourIntervalVariable, gsclient.DayIntervalVariable, gsclient.WeekIntervalVariable, gsclient.MonthIntervalVariable}
 
 const timeLayout = "2006-01-02 15:04:05"
 const (
@@ -0,0 +1,73 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGridscaleBackupLocations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGridscaleBackupLocationsRead,
+		Schema: map[string]*schema.Schema{
+			"backup_locations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Available backup locations, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"uuids_by_name": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "UUIDs of the backup locations by their names. It can be used to set backup_location_uuid of a backup schedule by name.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceGridscaleBackupLocationsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := "read backup locations datasource -"
+
+	locationList, err := client.GetStorageBackupLocationList(context.Background())
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	sort.SliceStable(locationList, func(i, j int) bool {
+		return locationList[i].Properties.Name < locationList[j].Properties.Name
+	})
+	locations := make([]interface{}, 0)
+	uuidsByName := make(map[string]interface{})
+	for _, value := range locationList {
+		prop := value.Properties
+		locations = append(locations, map[string]interface{}{
+			"object_uuid": prop.ObjectUUID,
+			"name":        prop.Name,
+		})
+		uuidsByName[prop.Name] = prop.ObjectUUID
+	}
+	d.SetId("backup_locations")
+
+	if err = d.Set("backup_locations", locations); err != nil {
+		return fmt.Errorf("%s error setting backup_locations: %v", errorPrefix, err)
+	}
+	if err = d.Set("uuids_by_name", uuidsByName); err != nil {
+		return fmt.Errorf("%s error setting uuids_by_name: %v", errorPrefix, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package gridscale
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccdataSourceGridscaleBackupLocations_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourceBackupLocationsConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.gridscale_backup_locations.foo", "id"),
+					resource.TestCheckResourceAttrSet("data.gridscale_backup_locations.foo", "backup_locations.0.object_uuid"),
+					resource.TestCheckResourceAttrSet("data.gridscale_backup_locations.foo", "backup_locations.0.name"),
+				),
+			},
+		},
+	})
+
+}
+
+func testAccCheckDataSourceBackupLocationsConfig_basic() string {
+	return `
+data "gridscale_backup_locations" "foo" {
+}`
+}
@@ -55,6 +55,22 @@ func dataSourceGridscaleFirewall() *schema.Resource {
 					Schema: getFirewallRuleCommonSchema(),
 				},
 			},
+			"default_rules_v4_in": {
+				Type:        schema.TypeList,
+				Description: "Default inbound rules (ipv4) which are enforced after the custom inbound rules.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: getFirewallRuleCommonSchema(),
+				},
+			},
+			"default_rules_v6_in": {
+				Type:        schema.TypeList,
+				Description: "Default inbound rules (ipv6) which are enforced after the custom inbound rules.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: getFirewallRuleCommonSchema(),
+				},
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Description: "Status indicates the status of the object",
@@ -175,12 +191,18 @@ func dataSourceGridscaleFirewallRead(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("%s error setting network: %v", errorPrefix, err)
 	}
 
-	//Get rules_v4_in
-	rulesV4InWODefaultRules := fwu.RemoveDefaultFirewallInboundRules(props.Rules.RulesV4In)
+	//Get rules_v4_in and default_rules_v4_in
+	rulesV4InWODefaultRules, defaultRulesV4In := fwu.SplitDefaultFirewallInboundRules(
+		props.Rules.RulesV4In,
+		fwu.GridscaleDefaultFirewallInboundRules(false),
+	)
 	rulesV4In := convFirewallRuleSliceToInterfaceSlice(rulesV4InWODefaultRules)
 	if err = d.Set("rules_v4_in", rulesV4In); err != nil {
 		return fmt.Errorf("%s error setting rules_v4_in: %v", errorPrefix, err)
 	}
+	if err = d.Set("default_rules_v4_in", convFirewallRuleSliceToInterfaceSlice(defaultRulesV4In)); err != nil {
+		return fmt.Errorf("%s error setting default_rules_v4_in: %v", errorPrefix, err)
+	}
 
 	//Get rules_v4_out
 	rulesV4Out := convFirewallRuleSliceToInterfaceSlice(props.Rules.RulesV4Out)
@@ -188,12 +210,18 @@ func dataSourceGridscaleFirewallRead(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("%s error setting rules_v4_out: %v", errorPrefix, err)
 	}
 
-	//Get rules_v6_in
-	rulesV6InWODefaultRules := fwu.RemoveDefaultFirewallInboundRules(props.Rules.RulesV6In)
+	//Get rules_v6_in and default_rules_v6_in
+	rulesV6InWODefaultRules, defaultRulesV6In := fwu.SplitDefaultFirewallInboundRules(
+		props.Rules.RulesV6In,
+		fwu.GridscaleDefaultFirewallInboundRules(true),
+	)
 	rulesV6In := convFirewallRuleSliceToInterfaceSlice(rulesV6InWODefaultRules)
 	if err = d.Set("rules_v6_in", rulesV6In); err != nil {
 		return fmt.Errorf("%s error setting rules_v6_in: %v", errorPrefix, err)
 	}
+	if err = d.Set("default_rules_v6_in", convFirewallRuleSliceToInterfaceSlice(defaultRulesV6In)); err != nil {
+		return fmt.Errorf("%s error setting default_rules_v6_in: %v", errorPrefix, err)
+	}
 
 	//Get rules_v6_out
 	rulesV6Out := convFirewallRuleSliceToInterfaceSlice(props.Rules.RulesV6Out)
@@ -0,0 +1,100 @@
+package gridscale
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceGridscaleFirewallAddressGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGridscaleFirewallAddressGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the address group.",
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "List of IPv4/6 addresses or IP networks in CIDR format which belong to the address group.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				},
+			},
+			"cidrs": {
+				Type:        schema.TypeList,
+				Description: "Sorted list of unique addresses and CIDRs of the address group. Can be used in src_cidrs and dst_cidrs of firewall rules.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ipv4_cidrs": {
+				Type:        schema.TypeList,
+				Description: "IPv4 addresses and CIDRs of the address group. Can be used in src_cidrs and dst_cidrs of IPv4 firewall rules.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ipv6_cidrs": {
+				Type:        schema.TypeList,
+				Description: "IPv6 addresses and CIDRs of the address group. Can be used in src_cidrs and dst_cidrs of IPv6 firewall rules.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceGridscaleFirewallAddressGroupRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	errorPrefix := fmt.Sprintf("read firewall address group (%s) datasource -", name)
+
+	cidrs := make([]string, 0)
+	ipv4Cidrs := make([]string, 0)
+	ipv6Cidrs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, addrIntf := range d.Get("addresses").([]interface{}) {
+		addr := strings.TrimSpace(addrIntf.(string))
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			var err error
+			if ip, _, err = net.ParseCIDR(addr); err != nil {
+				return fmt.Errorf("%s error: %s is neither an IP address nor a CIDR", errorPrefix, addr)
+			}
+		}
+		cidrs = append(cidrs, addr)
+		if ip.To4() != nil {
+			ipv4Cidrs = append(ipv4Cidrs, addr)
+		} else {
+			ipv6Cidrs = append(ipv6Cidrs, addr)
+		}
+	}
+	sort.Strings(cidrs)
+	sort.Strings(ipv4Cidrs)
+	sort.Strings(ipv6Cidrs)
+
+	d.SetId(name)
+
+	if err := d.Set("cidrs", cidrs); err != nil {
+		return fmt.Errorf("%s error setting cidrs: %v", errorPrefix, err)
+	}
+	if err := d.Set("ipv4_cidrs", ipv4Cidrs); err != nil {
+		return fmt.Errorf("%s error setting ipv4_cidrs: %v", errorPrefix, err)
+	}
+	if err := d.Set("ipv6_cidrs", ipv6Cidrs); err != nil {
+		return fmt.Errorf("%s error setting ipv6_cidrs: %v", errorPrefix, err)
+	}
+	return nil
+}
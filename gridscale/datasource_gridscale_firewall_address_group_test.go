@@ -0,0 +1,54 @@
+package gridscale
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccdataSourceGridscaleFirewallAddressGroup_basic(t *testing.T) {
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleFirewallDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+
+				Config: testAccCheckDataSourceFirewallAddressGroupConfig_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gridscale_firewall_address_group.office", "id", name),
+					resource.TestCheckResourceAttr("data.gridscale_firewall_address_group.office", "cidrs.#", "3"),
+					resource.TestCheckResourceAttr("data.gridscale_firewall_address_group.office", "ipv4_cidrs.#", "2"),
+					resource.TestCheckResourceAttr("data.gridscale_firewall_address_group.office", "ipv6_cidrs.#", "1"),
+					resource.TestCheckResourceAttr("gridscale_firewall.foo", "rules_v4_in.#", "1"),
+					resource.TestCheckResourceAttr("gridscale_firewall.foo", "rules_v4_in.0.service", "ssh"),
+				),
+			},
+		},
+	})
+
+}
+
+func testAccCheckDataSourceFirewallAddressGroupConfig_basic(name string) string {
+	return fmt.Sprintf(`
+data "gridscale_firewall_address_group" "office" {
+  name      = "%s"
+  addresses = ["10.0.0.0/24", "192.168.1.10", "2001:db8::/64", "10.0.0.0/24"]
+}
+
+resource "gridscale_firewall" "foo" {
+  name   = "%s"
+  rules_v4_in {
+	order = 0
+	service = "ssh"
+	action = "accept"
+	src_cidrs = data.gridscale_firewall_address_group.office.ipv4_cidrs
+	comment = "ssh from office"
+  }
+}
+`, name, name)
+}
@@ -0,0 +1,352 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	paasu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/paas-utils"
+)
+
+func dataSourceGridscalePaaSTemplates() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGridscalePaaSTemplatesRead,
+		Schema: map[string]*schema.Schema{
+			"flavour": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only return templates of this flavour (e.g. postgres, mysql, mariadb, sqlserver, redis-store, redis-cache, memcached, filesystem, kubernetes).",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"release": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Only return templates of this release.",
+				ValidateFunc:  validation.NoZeroValues,
+				ConflictsWith: []string{"latest_release"},
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only return templates of this version.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"performance_class": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only return templates of this performance class.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"latest_release": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Only return the templates of the newest release among the matching templates. It should be combined with flavour.",
+				ConflictsWith: []string{"release"},
+			},
+			"releases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Releases of the matching templates, sorted from the oldest to the newest.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"newest_release": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The newest release of the matching templates.",
+			},
+			"templates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching PaaS templates, sorted by flavour, release and performance class.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flavour": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"release": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"performance_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"product_no": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"memory": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The amount of memory required by the service, either RAM(MB) or SSD Storage(GB).",
+						},
+						"connections": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The amount of concurrent connections for the service.",
+						},
+						"storage_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_upgrades": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "UUIDs of the templates to which an upgrade is allowed.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"performance_class_updates": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "UUIDs of the templates to which a performance class update is allowed.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"patch_updates": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "UUIDs of the templates to which a patch update is allowed.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"autoscaling_cores_min": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"autoscaling_cores_max": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"autoscaling_storage_min": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"autoscaling_storage_max": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"parameters_schema": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Parameters which can be set on services of this template, sorted by name.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"description": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"required": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"empty": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"immutable": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"min": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"max": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"default": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The default value as string. Lists and maps are JSON encoded.",
+									},
+									"allowed": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"regex": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGridscalePaaSTemplatesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := "read paas templates datasource -"
+
+	paasTemplates, err := client.GetPaaSTemplateList(context.Background())
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	flavour := d.Get("flavour").(string)
+	release := d.Get("release").(string)
+	version := d.Get("version").(string)
+	performanceClass := d.Get("performance_class").(string)
+
+	var matches []gsclient.PaaSTemplateProperties
+	for _, template := range paasTemplates {
+		prop := template.Properties
+		if (flavour != "" && prop.Flavour != flavour) ||
+			(release != "" && prop.Release != release) ||
+			(version != "" && prop.Version != version) ||
+			(performanceClass != "" && prop.PerformanceClass != performanceClass) {
+			continue
+		}
+		matches = append(matches, prop)
+	}
+
+	releases := make([]string, 0)
+	seenReleases := make(map[string]bool)
+	for _, prop := range matches {
+		if !seenReleases[prop.Release] {
+			seenReleases[prop.Release] = true
+			releases = append(releases, prop.Release)
+		}
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return paasu.CompareReleases(releases[i], releases[j]) < 0
+	})
+	newestRelease := paasu.LatestRelease(releases)
+
+	if d.Get("latest_release").(bool) {
+		var latest []gsclient.PaaSTemplateProperties
+		for _, prop := range matches {
+			if prop.Release == newestRelease {
+				latest = append(latest, prop)
+			}
+		}
+		matches = latest
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Flavour != matches[j].Flavour {
+			return matches[i].Flavour < matches[j].Flavour
+		}
+		if c := paasu.CompareReleases(matches[i].Release, matches[j].Release); c != 0 {
+			return c < 0
+		}
+		return matches[i].PerformanceClass < matches[j].PerformanceClass
+	})
+
+	templates := make([]interface{}, 0)
+	for _, prop := range matches {
+		parametersSchema, err := flattenPaaSParametersSchema(prop.ParametersSchema)
+		if err != nil {
+			return fmt.Errorf("%s error flattening parameters_schema of template %s: %v", errorPrefix, prop.ObjectUUID, err)
+		}
+		templates = append(templates, map[string]interface{}{
+			"object_uuid":               prop.ObjectUUID,
+			"name":                      prop.Name,
+			"category":                  prop.Category,
+			"flavour":                   prop.Flavour,
+			"release":                   prop.Release,
+			"version":                   prop.Version,
+			"performance_class":         prop.PerformanceClass,
+			"status":                    prop.Status,
+			"product_no":                prop.ProductNo,
+			"labels":                    prop.Labels,
+			"memory":                    prop.Resources.Memory,
+			"connections":               prop.Resources.Connections,
+			"storage_type":              prop.Resources.StorageType,
+			"version_upgrades":          prop.VersionUpgrades,
+			"performance_class_updates": prop.PerformanceClassUpdates,
+			"patch_updates":             prop.PatchUpdates,
+			"autoscaling_cores_min":     prop.Autoscaling.Cores.Min,
+			"autoscaling_cores_max":     prop.Autoscaling.Cores.Max,
+			"autoscaling_storage_min":   prop.Autoscaling.Storage.Min,
+			"autoscaling_storage_max":   prop.Autoscaling.Storage.Max,
+			"parameters_schema":         parametersSchema,
+		})
+	}
+	d.SetId(fmt.Sprintf("paas_templates-%s", strings.Join([]string{
+		flavour, release, version, performanceClass, fmt.Sprintf("%t", d.Get("latest_release").(bool)),
+	}, "-")))
+
+	if err = d.Set("releases", releases); err != nil {
+		return fmt.Errorf("%s error setting releases: %v", errorPrefix, err)
+	}
+	if err = d.Set("newest_release", newestRelease); err != nil {
+		return fmt.Errorf("%s error setting newest_release: %v", errorPrefix, err)
+	}
+	if err = d.Set("templates", templates); err != nil {
+		return fmt.Errorf("%s error setting templates: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+// flattenPaaSParametersSchema converts the parameters schema of a PaaS template
+// to a list sorted by parameter name.
+func flattenPaaSParametersSchema(parametersSchema map[string]gsclient.Parameter) ([]interface{}, error) {
+	names := make([]string, 0, len(parametersSchema))
+	for name := range parametersSchema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parameters := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		param := parametersSchema[name]
+		defaultValue, err := paasu.FormatParameterValue(param.Default)
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name":        name,
+			"type":        param.Type,
+			"description": param.Description,
+			"required":    param.Required,
+			"empty":       param.Empty,
+			"immutable":   param.Immutable,
+			"min":         param.Min,
+			"max":         param.Max,
+			"default":     defaultValue,
+			"allowed":     param.Allowed,
+			"regex":       param.Regex,
+		})
+	}
+	return parameters, nil
+}
@@ -0,0 +1,45 @@
+package gridscale
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccdataSourceGridscalePaaSTemplates_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourcePaaSTemplatesConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.gridscale_paas_templates.all", "id"),
+					resource.TestCheckResourceAttrSet("data.gridscale_paas_templates.all", "newest_release"),
+					resource.TestCheckResourceAttr("data.gridscale_paas_templates.all", "templates.0.flavour", "postgres"),
+					resource.TestCheckResourceAttrSet("data.gridscale_paas_templates.all", "templates.0.object_uuid"),
+					resource.TestCheckResourceAttrSet("data.gridscale_paas_templates.all", "templates.0.parameters_schema.#"),
+					resource.TestCheckResourceAttr("data.gridscale_paas_templates.latest", "templates.0.performance_class", "standard"),
+					resource.TestCheckResourceAttr("data.gridscale_paas_templates.latest", "templates.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.gridscale_paas_templates.latest", "templates.0.release",
+						"data.gridscale_paas_templates.all", "newest_release"),
+				),
+			},
+		},
+	})
+
+}
+
+func testAccCheckDataSourcePaaSTemplatesConfig_basic() string {
+	return `
+data "gridscale_paas_templates" "all" {
+  flavour = "postgres"
+}
+
+data "gridscale_paas_templates" "latest" {
+  flavour = "postgres"
+  performance_class = "standard"
+  latest_release = true
+}`
+}
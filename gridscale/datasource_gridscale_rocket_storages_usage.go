@@ -0,0 +1,216 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func getUsageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"product_number": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"value": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceGridscaleRocketStoragesUsage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGridscaleRocketStoragesUsageRead,
+		Schema: map[string]*schema.Schema{
+			"query_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "project",
+				Description:  "Level of the usage query (one of project, contract).",
+				ValidateFunc: validation.StringInSlice(usageQueryLevels, false),
+			},
+			"from_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Starting time when the usage is calculated. Format: \"2006-01-02 15:04:05\"",
+				ValidateFunc: validateTimeLayout,
+			},
+			"to_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "End time when the usage is calculated. Format: \"2006-01-02 15:04:05\"",
+				ValidateFunc: validateTimeLayout,
+			},
+			"without_deleted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Calculate the usage without deleted rocket storages.",
+			},
+			"interval_variable": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Interval of usage_per_interval (one of H, D, W, M).",
+				ValidateFunc: validation.StringInSlice(usageIntervalVariables, false),
+			},
+			"rocket_storages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Usage of the rocket (local) storages.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"deleted": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_used_template": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"current_usage_per_minute": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: getUsageSchema(),
+							},
+						},
+						"usage_per_interval": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"interval_start": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"interval_end": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"accumulated_usage": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: getUsageSchema(),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateTimeLayout(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse(timeLayout, v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s is not a valid time (format: %q): %v", k, timeLayout, err))
+	}
+	return
+}
+
+func flattenUsages(usages []gsclient.Usage) []interface{} {
+	result := make([]interface{}, 0)
+	for _, usage := range usages {
+		result = append(result, map[string]interface{}{
+			"product_number": usage.ProductNumber,
+			"value":          usage.Value,
+		})
+	}
+	return result
+}
+
+func dataSourceGridscaleRocketStoragesUsageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := "read rocket storages usage datasource -"
+
+	queryLevel := gsclient.ProjectLevelUsage
+	if d.Get("query_level").(string) == "contract" {
+		queryLevel = gsclient.ContractLevelUsage
+	}
+	fromTime, err := time.Parse(timeLayout, d.Get("from_time").(string))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	var toTime *gsclient.GSTime
+	if attr, ok := d.GetOk("to_time"); ok {
+		parsedTime, err := time.Parse(timeLayout, attr.(string))
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		toTime = &gsclient.GSTime{Time: parsedTime}
+	}
+
+	usage, err := client.GetRocketStoragesUsage(
+		context.Background(),
+		queryLevel,
+		gsclient.GSTime{Time: fromTime},
+		toTime,
+		d.Get("without_deleted").(bool),
+		d.Get("interval_variable").(string),
+	)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	rocketStorages := make([]interface{}, 0)
+	for _, props := range usage.ResourcesUsage {
+		usagePerInterval := make([]interface{}, 0)
+		for _, interval := range props.UsagePerInterval {
+			usagePerInterval = append(usagePerInterval, map[string]interface{}{
+				"interval_start":    interval.IntervalStart.String(),
+				"interval_end":      interval.IntervalEnd.String(),
+				"accumulated_usage": flattenUsages(interval.AccumulatedUsage),
+			})
+		}
+		rocketStorages = append(rocketStorages, map[string]interface{}{
+			"object_uuid":              props.ObjectUUID,
+			"name":                     props.Name,
+			"labels":                   props.Labels,
+			"deleted":                  props.Deleted,
+			"status":                   props.Status,
+			"storage_type":             props.StorageType,
+			"last_used_template":       props.LastUsedTemplate,
+			"capacity":                 props.Capacity,
+			"current_usage_per_minute": flattenUsages(props.CurrentUsagePerMinute),
+			"usage_per_interval":       usagePerInterval,
+		})
+	}
+	d.SetId(fmt.Sprintf("rocket_storages_usage/%s/%s", d.Get("query_level").(string), d.Get("from_time").(string)))
+
+	if err = d.Set("rocket_storages", rocketStorages); err != nil {
+		return fmt.Errorf("%s error setting rocket_storages: %v", errorPrefix, err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package gridscale
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccdataSourceGridscaleRocketStoragesUsage_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourceRocketStoragesUsageConfig_basic("2021-01-01 00:00:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.gridscale_rocket_storages_usage.foo", "id"),
+					resource.TestCheckResourceAttrSet("data.gridscale_rocket_storages_usage.foo", "rocket_storages.#"),
+				),
+			},
+			{
+				Config:      testAccCheckDataSourceRocketStoragesUsageConfig_basic("2021-01-01"),
+				ExpectError: regexp.MustCompile("from_time is not a valid time"),
+			},
+		},
+	})
+
+}
+
+func testAccCheckDataSourceRocketStoragesUsageConfig_basic(fromTime string) string {
+	return `
+data "gridscale_rocket_storages_usage" "foo" {
+  from_time = "` + fromTime + `"
+  interval_variable = "D"
+}`
+}
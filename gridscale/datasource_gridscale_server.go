@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	fwu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/firewall-utils"
-
 	"github.com/gridscale/gsclient-go/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -164,6 +162,20 @@ func dataSourceGridscaleServer() *schema.Resource {
 								Schema: getFirewallRuleCommonSchema(),
 							},
 						},
+						"default_rules_v4_in": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: getFirewallRuleCommonSchema(),
+							},
+						},
+						"default_rules_v6_in": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: getFirewallRuleCommonSchema(),
+							},
+						},
 						"firewall_template_uuid": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -342,14 +354,7 @@ func dataSourceGridscaleServerRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	//Get networks
-	netWODefaultRules := server.Properties.Relations.Networks
-	for i := 0; i < len(netWODefaultRules); i++ { // Remove all default rules, we don't want to display them
-		netWODefaultRules[i].
-			Firewall.RulesV4In = fwu.RemoveDefaultFirewallInboundRules(netWODefaultRules[i].Firewall.RulesV4In)
-		netWODefaultRules[i].
-			Firewall.RulesV6In = fwu.RemoveDefaultFirewallInboundRules(netWODefaultRules[i].Firewall.RulesV6In)
-	}
-	networks, err := readServerNetworkRels(context.Background(), client, id, netWODefaultRules)
+	networks, err := readServerNetworkRels(context.Background(), client, id, server.Properties.Relations.Networks, nil)
 	if err != nil {
 		return fmt.Errorf("%s error reading server-network relations: %v", errorPrefix, err)
 	}
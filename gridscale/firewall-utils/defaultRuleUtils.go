@@ -1,74 +1,111 @@
 package fwu
 
-import "github.com/gridscale/gsclient-go/v3"
+import (
+	"sort"
 
-// AddDefaultFirewallInboundRules adds default fw rules
-func AddDefaultFirewallInboundRules(rules []gsclient.FirewallRuleProperties, forIPv6 bool) []gsclient.FirewallRuleProperties {
-	if len(rules) == 0 { // If no custom fw rules are added, no need to add default ones
-		return rules
-	}
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+// Modes of default inbound firewall rules
+const (
+	// DefaultInboundRulesGridscale appends the gridscale default inbound rules
+	// (DHCP, high ports, drop all other traffic) to custom inbound rules.
+	DefaultInboundRulesGridscale = "gridscale"
+	// DefaultInboundRulesNone does not append any default inbound rules.
+	DefaultInboundRulesNone = "none"
+	// DefaultInboundRulesCustom appends a user-defined list of default inbound rules.
+	DefaultInboundRulesCustom = "custom"
+)
+
+// DefaultInboundRulesModes defines all supported modes of default inbound rules
+var DefaultInboundRulesModes = []string{DefaultInboundRulesGridscale, DefaultInboundRulesNone, DefaultInboundRulesCustom}
+
+// GridscaleDefaultFirewallInboundRules returns the gridscale default inbound rules.
+// The orders of the returned rules start at 0, they are shifted when the rules
+// are added to custom rules.
+func GridscaleDefaultFirewallInboundRules(forIPv6 bool) []gsclient.FirewallRuleProperties {
 	srcCidr := "0.0.0.0/0"
 	DHCPDstPort := "67:68"
 	DHCPComment := "DHCP IPv4"
-	nextOrder := getNextFWRuleOrder(rules)
 	if forIPv6 {
 		srcCidr = "::/0"
 		DHCPDstPort = "546:547"
 		DHCPComment = "DHCP IPv6"
 	}
-	defaultInboundRules := []gsclient.FirewallRuleProperties{
+	return []gsclient.FirewallRuleProperties{
 		{
 			Protocol: gsclient.UDPTransport,
 			DstPort:  DHCPDstPort,
-			SrcPort:  "",
 			SrcCidr:  srcCidr,
 			Action:   "accept",
 			Comment:  DHCPComment,
-			DstCidr:  "",
-			Order:    nextOrder,
+			Order:    0,
 		},
 		{
 			Protocol: gsclient.TCPTransport,
 			DstPort:  "32768:65535",
-			SrcPort:  "",
 			SrcCidr:  srcCidr,
 			Action:   "accept",
 			Comment:  "Highports TCP",
-			DstCidr:  "",
-			Order:    nextOrder + 1,
+			Order:    1,
 		},
 		{
 			Protocol: gsclient.UDPTransport,
 			DstPort:  "32768:65535",
-			SrcPort:  "",
 			SrcCidr:  srcCidr,
 			Action:   "accept",
 			Comment:  "Highports UDP",
-			DstCidr:  "",
-			Order:    nextOrder + 2,
+			Order:    2,
 		},
 		{
 			Protocol: gsclient.UDPTransport,
 			DstPort:  "1:65535",
-			SrcPort:  "",
 			SrcCidr:  srcCidr,
 			Action:   "drop",
 			Comment:  "Drop all other UDP",
-			DstCidr:  "",
-			Order:    nextOrder + 3,
+			Order:    3,
 		},
 		{
 			Protocol: gsclient.TCPTransport,
 			DstPort:  "1:65535",
-			SrcPort:  "",
 			SrcCidr:  srcCidr,
 			Action:   "drop",
 			Comment:  "Drop all other TCP",
-			DstCidr:  "",
-			Order:    nextOrder + 4,
+			Order:    4,
 		},
 	}
-	rules = append(rules, defaultInboundRules...)
+}
+
+// DefaultFirewallInboundRules returns the default inbound rules of a specific mode.
+// customRules is only used in mode DefaultInboundRulesCustom. An empty mode
+// is treated as DefaultInboundRulesGridscale.
+func DefaultFirewallInboundRules(mode string, customRules []gsclient.FirewallRuleProperties, forIPv6 bool) []gsclient.FirewallRuleProperties {
+	switch mode {
+	case DefaultInboundRulesNone:
+		return nil
+	case DefaultInboundRulesCustom:
+		rules := make([]gsclient.FirewallRuleProperties, 0, len(customRules))
+		for i, rule := range customRules {
+			rule.Order = i
+			rules = append(rules, rule)
+		}
+		return rules
+	default:
+		return GridscaleDefaultFirewallInboundRules(forIPv6)
+	}
+}
+
+// AddDefaultFirewallInboundRules appends default fw rules to custom fw rules.
+// The default rules are ordered after the custom rule with the highest order.
+func AddDefaultFirewallInboundRules(rules, defaultRules []gsclient.FirewallRuleProperties) []gsclient.FirewallRuleProperties {
+	if len(rules) == 0 { // If no custom fw rules are added, no need to add default ones
+		return rules
+	}
+	nextOrder := getNextFWRuleOrder(rules)
+	for i, rule := range defaultRules {
+		rule.Order = nextOrder + i
+		rules = append(rules, rule)
+	}
 	return rules
 }
 
@@ -82,22 +119,64 @@ func getNextFWRuleOrder(rules []gsclient.FirewallRuleProperties) int {
 	return max + 1
 }
 
-// RemoveDefaultFirewallInboundRules removes default fw rules
-// It is used when we don't want to display the default fw rules in tf
-func RemoveDefaultFirewallInboundRules(rules []gsclient.FirewallRuleProperties) []gsclient.FirewallRuleProperties {
-	defaultRulesNames := map[string]int{
-		"DHCP IPv4":          0,
-		"DHCP IPv6":          0,
-		"Highports TCP":      0,
-		"Highports UDP":      0,
-		"Drop all other UDP": 0,
-		"Drop all other TCP": 0,
+// SplitDefaultFirewallInboundRules separates default fw rules from custom fw rules.
+// Default rules are identified by their content (protocol, ports, CIDRs and action),
+// not by their comments: they are only recognized when the rules with the highest orders
+// match defaultRules one by one. Rules which are not recognized as default rules
+// are returned as custom rules in their original sequence.
+func SplitDefaultFirewallInboundRules(rules, defaultRules []gsclient.FirewallRuleProperties) (customRules, foundDefaultRules []gsclient.FirewallRuleProperties) {
+	customRules = make([]gsclient.FirewallRuleProperties, 0, len(rules))
+	foundDefaultRules = make([]gsclient.FirewallRuleProperties, 0, len(defaultRules))
+	if len(defaultRules) == 0 || len(rules) <= len(defaultRules) {
+		return append(customRules, rules...), foundDefaultRules
 	}
-	for i := 0; i < len(rules); i++ {
-		if _, ok := defaultRulesNames[rules[i].Comment]; ok {
-			rules = append(rules[:i], rules[i+1:]...)
-			i-- // As we've just deleted rules[i], we'd to redo that index
+	// Sort indices of rules by rule order, so that the original sequence is kept.
+	indices := make([]int, len(rules))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool { return rules[indices[i]].Order < rules[indices[j]].Order })
+	tail := indices[len(indices)-len(defaultRules):]
+	isDefault := make(map[int]bool)
+	for i, idx := range tail {
+		if !IsSameFirewallRuleContent(rules[idx], defaultRules[i]) {
+			return append(customRules, rules...), foundDefaultRules
 		}
+		isDefault[idx] = true
+		foundDefaultRules = append(foundDefaultRules, rules[idx])
 	}
-	return rules
+	for i, rule := range rules {
+		if !isDefault[i] {
+			customRules = append(customRules, rule)
+		}
+	}
+	return customRules, foundDefaultRules
+}
+
+// HasDefaultFirewallInboundRules checks if rules end with defaultRules, i.e. if the rules
+// were created with the same default inbound rules. Rules without custom rules have no
+// default rules at all. Empty defaultRules (mode DefaultInboundRulesNone) do not match
+// rules which end with the gridscale default inbound rules.
+func HasDefaultFirewallInboundRules(rules, defaultRules []gsclient.FirewallRuleProperties, forIPv6 bool) bool {
+	if len(rules) == 0 {
+		return true
+	}
+	if len(defaultRules) == 0 {
+		gridscaleDefaultRules := GridscaleDefaultFirewallInboundRules(forIPv6)
+		_, foundDefaultRules := SplitDefaultFirewallInboundRules(rules, gridscaleDefaultRules)
+		return len(foundDefaultRules) != len(gridscaleDefaultRules)
+	}
+	_, foundDefaultRules := SplitDefaultFirewallInboundRules(rules, defaultRules)
+	return len(foundDefaultRules) == len(defaultRules)
+}
+
+// IsSameFirewallRuleContent checks if two fw rules filter the same traffic
+// with the same action. Orders and comments are ignored.
+func IsSameFirewallRuleContent(a, b gsclient.FirewallRuleProperties) bool {
+	return a.Protocol == b.Protocol &&
+		a.DstPort == b.DstPort &&
+		a.SrcPort == b.SrcPort &&
+		a.SrcCidr == b.SrcCidr &&
+		a.DstCidr == b.DstCidr &&
+		a.Action == b.Action
 }
@@ -0,0 +1,147 @@
+package fwu
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func TestSplitDefaultFirewallInboundRules(t *testing.T) {
+	customRules := []gsclient.FirewallRuleProperties{
+		{
+			Protocol: gsclient.TCPTransport,
+			DstPort:  "22",
+			Action:   "accept",
+			Comment:  "ssh",
+			Order:    0,
+		},
+		{
+			Protocol: gsclient.TCPTransport,
+			DstPort:  "32768:65535",
+			SrcCidr:  "0.0.0.0/0",
+			Action:   "accept",
+			Comment:  "Highports TCP",
+			Order:    1,
+		},
+	}
+	defaultRules := GridscaleDefaultFirewallInboundRules(false)
+	rulesWithDefault := AddDefaultFirewallInboundRules(append([]gsclient.FirewallRuleProperties{}, customRules...), defaultRules)
+
+	type testCase struct {
+		Rules                []gsclient.FirewallRuleProperties
+		DefaultRules         []gsclient.FirewallRuleProperties
+		ExpectedCustomRules  []gsclient.FirewallRuleProperties
+		ExpectedDefaultRules []gsclient.FirewallRuleProperties
+	}
+	testCases := []testCase{
+		{
+			// custom rules commented like default rules are kept
+			Rules:                rulesWithDefault,
+			DefaultRules:         defaultRules,
+			ExpectedCustomRules:  customRules,
+			ExpectedDefaultRules: rulesWithDefault[len(customRules):],
+		},
+		{
+			Rules:                customRules,
+			DefaultRules:         defaultRules,
+			ExpectedCustomRules:  customRules,
+			ExpectedDefaultRules: []gsclient.FirewallRuleProperties{},
+		},
+		{
+			Rules:                rulesWithDefault,
+			DefaultRules:         DefaultFirewallInboundRules(DefaultInboundRulesNone, nil, false),
+			ExpectedCustomRules:  rulesWithDefault,
+			ExpectedDefaultRules: []gsclient.FirewallRuleProperties{},
+		},
+		{
+			Rules:                rulesWithDefault,
+			DefaultRules:         GridscaleDefaultFirewallInboundRules(true),
+			ExpectedCustomRules:  rulesWithDefault,
+			ExpectedDefaultRules: []gsclient.FirewallRuleProperties{},
+		},
+	}
+	for _, tCase := range testCases {
+		resultCustomRules, resultDefaultRules := SplitDefaultFirewallInboundRules(tCase.Rules, tCase.DefaultRules)
+		if !reflect.DeepEqual(resultCustomRules, tCase.ExpectedCustomRules) {
+			t.Errorf("Custom rules output: %v, Expected: %v", resultCustomRules, tCase.ExpectedCustomRules)
+		}
+		if !reflect.DeepEqual(resultDefaultRules, tCase.ExpectedDefaultRules) {
+			t.Errorf("Default rules output: %v, Expected: %v", resultDefaultRules, tCase.ExpectedDefaultRules)
+		}
+	}
+}
+
+func TestHasDefaultFirewallInboundRules(t *testing.T) {
+	customRules := []gsclient.FirewallRuleProperties{
+		{
+			Protocol: gsclient.TCPTransport,
+			DstPort:  "22",
+			Action:   "accept",
+			Order:    0,
+		},
+	}
+	customDefaultRules := DefaultFirewallInboundRules(DefaultInboundRulesCustom, []gsclient.FirewallRuleProperties{
+		{
+			Protocol: gsclient.TCPTransport,
+			DstPort:  "443",
+			Action:   "accept",
+		},
+	}, false)
+	gridscaleDefaultRules := GridscaleDefaultFirewallInboundRules(false)
+	rulesWithGridscaleDefault := AddDefaultFirewallInboundRules(append([]gsclient.FirewallRuleProperties{}, customRules...), gridscaleDefaultRules)
+	rulesWithCustomDefault := AddDefaultFirewallInboundRules(append([]gsclient.FirewallRuleProperties{}, customRules...), customDefaultRules)
+
+	type testCase struct {
+		Rules          []gsclient.FirewallRuleProperties
+		DefaultRules   []gsclient.FirewallRuleProperties
+		ExpectedResult bool
+	}
+	testCases := []testCase{
+		{
+			Rules:          nil,
+			DefaultRules:   gridscaleDefaultRules,
+			ExpectedResult: true,
+		},
+		{
+			Rules:          rulesWithGridscaleDefault,
+			DefaultRules:   gridscaleDefaultRules,
+			ExpectedResult: true,
+		},
+		{
+			Rules:          rulesWithGridscaleDefault,
+			DefaultRules:   customDefaultRules,
+			ExpectedResult: false,
+		},
+		{
+			Rules:          rulesWithGridscaleDefault,
+			DefaultRules:   nil,
+			ExpectedResult: false,
+		},
+		{
+			Rules:          rulesWithCustomDefault,
+			DefaultRules:   customDefaultRules,
+			ExpectedResult: true,
+		},
+		{
+			Rules:          rulesWithCustomDefault,
+			DefaultRules:   gridscaleDefaultRules,
+			ExpectedResult: false,
+		},
+		{
+			Rules:          customRules,
+			DefaultRules:   nil,
+			ExpectedResult: true,
+		},
+		{
+			Rules:          customRules,
+			DefaultRules:   gridscaleDefaultRules,
+			ExpectedResult: false,
+		},
+	}
+	for i, tCase := range testCases {
+		if result := HasDefaultFirewallInboundRules(tCase.Rules, tCase.DefaultRules, false); result != tCase.ExpectedResult {
+			t.Errorf("test case %d: output: %v, Expected: %v", i, result, tCase.ExpectedResult)
+		}
+	}
+}
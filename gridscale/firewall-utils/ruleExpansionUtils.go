@@ -0,0 +1,171 @@
+package fwu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+// FirewallService represents a well-known service which can be referenced by its name in firewall rules
+type FirewallService struct {
+	Protocol gsclient.TransportLayerProtocol
+	DstPort  string
+}
+
+// FirewallServices is the catalog of services which can be referenced by their names in firewall rules
+var FirewallServices = map[string]FirewallService{
+	"dns":            {Protocol: gsclient.UDPTransport, DstPort: "53"},
+	"dns-tcp":        {Protocol: gsclient.TCPTransport, DstPort: "53"},
+	"ftp":            {Protocol: gsclient.TCPTransport, DstPort: "20:21"},
+	"http":           {Protocol: gsclient.TCPTransport, DstPort: "80"},
+	"https":          {Protocol: gsclient.TCPTransport, DstPort: "443"},
+	"imaps":          {Protocol: gsclient.TCPTransport, DstPort: "993"},
+	"kubernetes-api": {Protocol: gsclient.TCPTransport, DstPort: "6443"},
+	"ldap":           {Protocol: gsclient.TCPTransport, DstPort: "389"},
+	"ldaps":          {Protocol: gsclient.TCPTransport, DstPort: "636"},
+	"memcached":      {Protocol: gsclient.TCPTransport, DstPort: "11211"},
+	"mongodb":        {Protocol: gsclient.TCPTransport, DstPort: "27017"},
+	"mssql":          {Protocol: gsclient.TCPTransport, DstPort: "1433"},
+	"mysql":          {Protocol: gsclient.TCPTransport, DstPort: "3306"},
+	"nfs":            {Protocol: gsclient.TCPTransport, DstPort: "2049"},
+	"ntp":            {Protocol: gsclient.UDPTransport, DstPort: "123"},
+	"openvpn":        {Protocol: gsclient.UDPTransport, DstPort: "1194"},
+	"pop3s":          {Protocol: gsclient.TCPTransport, DstPort: "995"},
+	"postgres":       {Protocol: gsclient.TCPTransport, DstPort: "5432"},
+	"rdp":            {Protocol: gsclient.TCPTransport, DstPort: "3389"},
+	"redis":          {Protocol: gsclient.TCPTransport, DstPort: "6379"},
+	"smtp":           {Protocol: gsclient.TCPTransport, DstPort: "25"},
+	"smtps":          {Protocol: gsclient.TCPTransport, DstPort: "465"},
+	"ssh":            {Protocol: gsclient.TCPTransport, DstPort: "22"},
+	"submission":     {Protocol: gsclient.TCPTransport, DstPort: "587"},
+	"wireguard":      {Protocol: gsclient.UDPTransport, DstPort: "51820"},
+}
+
+// FirewallServiceNames returns the sorted names of all services in the catalog
+func FirewallServiceNames() []string {
+	names := make([]string, 0, len(FirewallServices))
+	for name := range FirewallServices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExpandFirewallRule expands a firewall rule which references a service and/or lists of CIDRs
+// to concrete firewall rules. One rule is created per combination of source and destination CIDRs,
+// the rules are ordered consecutively starting at the order of the rule
+// (e.g. a rule with order 10 and 3 source CIDRs is expanded to rules with orders 10, 11 and 12).
+func ExpandFirewallRule(rule gsclient.FirewallRuleProperties, service string, srcCidrs, dstCidrs []string) ([]gsclient.FirewallRuleProperties, error) {
+	if service != "" {
+		if rule.Protocol != "" || rule.DstPort != "" {
+			return nil, fmt.Errorf("rule with order %d: service cannot be combined with protocol or dst_port", rule.Order)
+		}
+		svc, ok := FirewallServices[service]
+		if !ok {
+			return nil, fmt.Errorf("rule with order %d: %s is not a valid service. Valid services are: %s", rule.Order, service, strings.Join(FirewallServiceNames(), ","))
+		}
+		rule.Protocol = svc.Protocol
+		rule.DstPort = svc.DstPort
+	}
+	if rule.Protocol == "" {
+		return nil, fmt.Errorf("rule with order %d: either protocol or service is required", rule.Order)
+	}
+	if len(srcCidrs) > 0 && rule.SrcCidr != "" {
+		return nil, fmt.Errorf("rule with order %d: src_cidrs cannot be combined with src_cidr", rule.Order)
+	}
+	if len(dstCidrs) > 0 && rule.DstCidr != "" {
+		return nil, fmt.Errorf("rule with order %d: dst_cidrs cannot be combined with dst_cidr", rule.Order)
+	}
+	if len(srcCidrs) == 0 {
+		srcCidrs = []string{rule.SrcCidr}
+	}
+	if len(dstCidrs) == 0 {
+		dstCidrs = []string{rule.DstCidr}
+	}
+	rules := make([]gsclient.FirewallRuleProperties, 0, len(srcCidrs)*len(dstCidrs))
+	for _, srcCidr := range srcCidrs {
+		for _, dstCidr := range dstCidrs {
+			expandedRule := rule
+			expandedRule.SrcCidr = srcCidr
+			expandedRule.DstCidr = dstCidr
+			expandedRule.Order = rule.Order + len(rules)
+			rules = append(rules, expandedRule)
+		}
+	}
+	return rules, nil
+}
+
+// CheckExpandedFirewallRuleOrders checks that the orders of rules, which are expanded from one
+// rule to multiple rules, do not overlap with the orders of other rules.
+// expandedRules contains the expanded rules of each rule.
+func CheckExpandedFirewallRuleOrders(expandedRules [][]gsclient.FirewallRuleProperties) error {
+	orders := make(map[int]int)
+	for i, rules := range expandedRules {
+		if len(rules) > 0 {
+			orders[rules[0].Order] = i
+		}
+	}
+	for i, rules := range expandedRules {
+		for j := 1; j < len(rules); j++ {
+			if k, ok := orders[rules[j].Order]; ok && k != i {
+				return fmt.Errorf(
+					"rule with order %d is expanded to %d rules (orders %d-%d), which overlap with the rule with order %d. Please leave a gap between the orders of the rules",
+					rules[0].Order, len(rules), rules[0].Order, rules[0].Order+len(rules)-1, rules[j].Order,
+				)
+			}
+			orders[rules[j].Order] = i
+		}
+	}
+	return nil
+}
+
+// ConvInterfaceSliceToFirewallRules converts slice of interface (firewall rules declared in terraform)
+// to slice of concrete firewall rules. Services and lists of CIDRs are expanded.
+func ConvInterfaceSliceToFirewallRules(interfaceRules []interface{}) ([]gsclient.FirewallRuleProperties, error) {
+	var firewallRules []gsclient.FirewallRuleProperties
+	expandedRules := make([][]gsclient.FirewallRuleProperties, 0, len(interfaceRules))
+	for _, value := range interfaceRules {
+		rule := value.(map[string]interface{})
+		fwRule := gsclient.FirewallRuleProperties{
+			DstPort: rule["dst_port"].(string),
+			SrcPort: rule["src_port"].(string),
+			SrcCidr: rule["src_cidr"].(string),
+			Action:  rule["action"].(string),
+			Comment: rule["comment"].(string),
+			DstCidr: rule["dst_cidr"].(string),
+		}
+		//Default rules do not have an order, it is given by their position
+		if order, ok := rule["order"].(int); ok {
+			fwRule.Order = order
+		}
+		if rule["protocol"].(string) == "tcp" {
+			fwRule.Protocol = gsclient.TCPTransport
+		} else if rule["protocol"].(string) == "udp" {
+			fwRule.Protocol = gsclient.UDPTransport
+		}
+		service, _ := rule["service"].(string)
+		var srcCidrs, dstCidrs []string
+		if cidrs, ok := rule["src_cidrs"].([]interface{}); ok {
+			for _, cidr := range cidrs {
+				srcCidrs = append(srcCidrs, cidr.(string))
+			}
+		}
+		if cidrs, ok := rule["dst_cidrs"].([]interface{}); ok {
+			for _, cidr := range cidrs {
+				dstCidrs = append(dstCidrs, cidr.(string))
+			}
+		}
+		rules, err := ExpandFirewallRule(fwRule, service, srcCidrs, dstCidrs)
+		if err != nil {
+			return nil, err
+		}
+		expandedRules = append(expandedRules, rules)
+		firewallRules = append(firewallRules, rules...)
+	}
+	if err := CheckExpandedFirewallRuleOrders(expandedRules); err != nil {
+		return nil, err
+	}
+	return firewallRules, nil
+}
@@ -0,0 +1,93 @@
+package fwu
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func TestConvInterfaceSliceToFirewallRules(t *testing.T) {
+	type testCase struct {
+		Rules         []interface{}
+		ExpectedRules []gsclient.FirewallRuleProperties
+		ExpectError   bool
+	}
+	rule := func(order int, protocol, dstPort, srcCidrs, service string, cidrs ...string) map[string]interface{} {
+		r := map[string]interface{}{
+			"order":    order,
+			"protocol": protocol,
+			"dst_port": dstPort,
+			"src_port": "",
+			"src_cidr": srcCidrs,
+			"dst_cidr": "",
+			"action":   "accept",
+			"comment":  "",
+			"service":  service,
+		}
+		srcCidrsIntf := make([]interface{}, 0)
+		for _, cidr := range cidrs {
+			srcCidrsIntf = append(srcCidrsIntf, cidr)
+		}
+		r["src_cidrs"] = srcCidrsIntf
+		r["dst_cidrs"] = []interface{}{}
+		return r
+	}
+	testCases := []testCase{
+		{
+			Rules: []interface{}{rule(0, "tcp", "80", "", "")},
+			ExpectedRules: []gsclient.FirewallRuleProperties{
+				{Protocol: gsclient.TCPTransport, DstPort: "80", Action: "accept", Order: 0},
+			},
+		},
+		{
+			Rules: []interface{}{
+				rule(0, "", "", "", "ssh", "10.0.0.0/24", "192.168.1.10"),
+				rule(10, "", "", "", "postgres"),
+			},
+			ExpectedRules: []gsclient.FirewallRuleProperties{
+				{Protocol: gsclient.TCPTransport, DstPort: "22", SrcCidr: "10.0.0.0/24", Action: "accept", Order: 0},
+				{Protocol: gsclient.TCPTransport, DstPort: "22", SrcCidr: "192.168.1.10", Action: "accept", Order: 1},
+				{Protocol: gsclient.TCPTransport, DstPort: "5432", Action: "accept", Order: 10},
+			},
+		},
+		{
+			// expanded orders overlap with the order of the next rule
+			Rules: []interface{}{
+				rule(0, "", "", "", "ssh", "10.0.0.0/24", "192.168.1.10"),
+				rule(1, "tcp", "80", "", ""),
+			},
+			ExpectError: true,
+		},
+		{
+			// service cannot be combined with protocol
+			Rules:       []interface{}{rule(0, "tcp", "", "", "ssh")},
+			ExpectError: true,
+		},
+		{
+			// src_cidrs cannot be combined with src_cidr
+			Rules:       []interface{}{rule(0, "tcp", "22", "10.0.0.1", "", "10.0.0.0/24")},
+			ExpectError: true,
+		},
+		{
+			// either protocol or service is required
+			Rules:       []interface{}{rule(0, "", "22", "", "")},
+			ExpectError: true,
+		},
+	}
+	for _, tCase := range testCases {
+		rules, err := ConvInterfaceSliceToFirewallRules(tCase.Rules)
+		if tCase.ExpectError {
+			if err == nil {
+				t.Errorf("Expected error for rules %v, got rules %v", tCase.Rules, rules)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(rules, tCase.ExpectedRules) {
+			t.Errorf("Rules output: %v, Expected: %v", rules, tCase.ExpectedRules)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package gridscale
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+// isoImagePresignedURLExpiry is the validity of the presigned URL which gridscale
+// uses to fetch an uploaded ISO image from the object storage.
+const isoImagePresignedURLExpiry = 1 * time.Hour
+
+// fileSHA256 returns the hex encoded SHA256 checksum of a local file.
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// uploadISOImageToObjectStorage uploads a local ISO file to an object storage bucket
+// and returns a presigned URL of the uploaded object. Large files are uploaded in multiple parts.
+func uploadISOImageToObjectStorage(ctx context.Context, sourceFile string, objStorage map[string]interface{}) (string, error) {
+	host := objStorage["host"].(string)
+	bucket := objStorage["bucket"].(string)
+	object := objStorage["object"].(string)
+	s3Client := initS3Client(&gridscaleS3Provider{
+		AccessKey: objStorage["access_key"].(string),
+		SecretKey: objStorage["secret_key"].(string),
+	}, host)
+
+	file, err := os.Open(sourceFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	log.Printf("Start uploading ISO image %s to %s/%s", sourceFile, bucket, object)
+	uploader := s3manager.NewUploaderWithClient(s3Client)
+	_, err = uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+		Body:   file,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error uploading %s to %s/%s in object storage %s: %v", sourceFile, bucket, object, host, err)
+	}
+	log.Printf("Uploading ISO image %s to %s/%s SUCCESSFULLY", sourceFile, bucket, object)
+
+	req, _ := s3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	presignedURL, err := req.Presign(isoImagePresignedURLExpiry)
+	if err != nil {
+		return "", fmt.Errorf("error presigning URL of %s/%s: %v", bucket, object, err)
+	}
+	return presignedURL, nil
+}
+
+// deleteISOImageStagingObject deletes an uploaded ISO file from the object storage.
+func deleteISOImageStagingObject(ctx context.Context, objStorage map[string]interface{}) error {
+	s3Client := initS3Client(&gridscaleS3Provider{
+		AccessKey: objStorage["access_key"].(string),
+		SecretKey: objStorage["secret_key"].(string),
+	}, objStorage["host"].(string))
+	_, err := s3Client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(objStorage["bucket"].(string)),
+		Key:    aws.String(objStorage["object"].(string)),
+	})
+	return err
+}
+
+// waitForISOImageActive waits until gridscale has fetched an ISO image from its source URL.
+func waitForISOImageActive(ctx context.Context, client *gsclient.Client, id string) error {
+	for {
+		iso, err := client.GetISOImage(ctx, id)
+		if err != nil {
+			return err
+		}
+		if iso.Properties.Status == "active" {
+			return nil
+		}
+		log.Printf("[DEBUG] Waiting for ISO image (%s) to be active, current status: %s", id, iso.Properties.Status)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for ISO image (%s) to be active: %v", id, ctx.Err())
+		case <-time.After(client.DelayInterval()):
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package gridscale
+
+import (
+	"log"
+	"sync"
+)
+
+// objectLockList represents a list of locks of gridscale objects (e.g. firewalls)
+// which are modified in a read-modify-write manner by multiple resources in terraform.
+// mutex is used to lock when adding new locks to the list.
+type objectLockList struct {
+	list map[string]*sync.Mutex
+	mux  sync.Mutex
+}
+
+// getLock returns the lock of an object, the lock is created if it does not exist yet
+func (l *objectLockList) getLock(id string) *sync.Mutex {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if _, ok := l.list[id]; !ok {
+		l.list[id] = &sync.Mutex{}
+	}
+	return l.list[id]
+}
+
+// lock locks an object. That means the object can only be modified
+// by one goroutine at a time.
+func (l *objectLockList) lock(id string) {
+	l.getLock(id).Lock()
+	log.Printf("[DEBUG] LOCK ACQUIRED to modify object (%v)", id)
+}
+
+// unlock unlocks an object which is locked by `lock`
+func (l *objectLockList) unlock(id string) {
+	l.getLock(id).Unlock()
+	log.Printf("[DEBUG] LOCK RELEASED! Modifying object (%v) is done", id)
+}
+
+// globalObjectLockList global list of locks of all objects which are modified
+// by multiple resources in terraform
+var globalObjectLockList = objectLockList{
+	list: make(map[string]*sync.Mutex),
+}
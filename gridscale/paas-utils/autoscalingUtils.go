@@ -0,0 +1,16 @@
+package paasu
+
+import "fmt"
+
+// ValidateAutoscalingLimit checks a configured autoscaling maximum against the autoscaling
+// bounds (min, max) of a service template. A max of 0 means that the template does not
+// support the autoscaling of this resource.
+func ValidateAutoscalingLimit(value, min, max int) error {
+	if max == 0 {
+		return fmt.Errorf("the service template does not support this autoscaling")
+	}
+	if value < min || value > max {
+		return fmt.Errorf("value must stay between %d and %d", min, max)
+	}
+	return nil
+}
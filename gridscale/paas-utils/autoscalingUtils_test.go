@@ -0,0 +1,29 @@
+package paasu
+
+import "testing"
+
+func TestValidateAutoscalingLimit(t *testing.T) {
+	tests := []struct {
+		value    int
+		min      int
+		max      int
+		expected string
+	}{
+		{4, 1, 16, ""},
+		{1, 1, 16, ""},
+		{16, 1, 16, ""},
+		{0, 1, 16, "value must stay between 1 and 16"},
+		{1000, 1, 16, "value must stay between 1 and 16"},
+		{4, 0, 0, "the service template does not support this autoscaling"},
+	}
+	for _, test := range tests {
+		err := ValidateAutoscalingLimit(test.value, test.min, test.max)
+		var result string
+		if err != nil {
+			result = err.Error()
+		}
+		if result != test.expected {
+			t.Errorf("ValidateAutoscalingLimit(%d, %d, %d) = %q, expected %q", test.value, test.min, test.max, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package paasu
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+// Types of PaaS parameters (python-cerberus compatible)
+const (
+	ParameterTypeString  = "string"
+	ParameterTypeInteger = "integer"
+	ParameterTypeFloat   = "float"
+	ParameterTypeNumber  = "number"
+	ParameterTypeBoolean = "boolean"
+	ParameterTypeList    = "list"
+	ParameterTypeDict    = "dict"
+)
+
+// ParseParameterValue converts the string value of a PaaS parameter to the type
+// defined in the parameters schema of the PaaS template.
+// Lists and dicts are expected to be JSON encoded.
+func ParseParameterValue(value string, param gsclient.Parameter) (interface{}, error) {
+	switch param.Type {
+	case ParameterTypeString, "":
+		return value, nil
+	case ParameterTypeInteger:
+		return strconv.Atoi(value)
+	case ParameterTypeFloat, ParameterTypeNumber:
+		return strconv.ParseFloat(value, 64)
+	case ParameterTypeBoolean:
+		return strconv.ParseBool(value)
+	case ParameterTypeList:
+		var list []interface{}
+		err := json.Unmarshal([]byte(value), &list)
+		return list, err
+	case ParameterTypeDict:
+		var dict map[string]interface{}
+		err := json.Unmarshal([]byte(value), &dict)
+		return dict, err
+	default:
+		return nil, fmt.Errorf("type %s is not supported", param.Type)
+	}
+}
+
+// ValidateParameterValue validates a typed value of a PaaS parameter against
+// the min/max, allowed values and regex of the parameters schema.
+func ValidateParameterValue(value interface{}, param gsclient.Parameter) error {
+	var number *float64
+	switch v := value.(type) {
+	case int:
+		f := float64(v)
+		number = &f
+	case float64:
+		number = &v
+	}
+	if number != nil && (param.Min != 0 || param.Max != 0) {
+		if *number < float64(param.Min) || *number > float64(param.Max) {
+			return fmt.Errorf("value must be between %d and %d", param.Min, param.Max)
+		}
+	}
+	if len(param.Allowed) > 0 {
+		// The allowed values of a list apply to its items
+		items := []interface{}{value}
+		if list, ok := value.([]interface{}); ok {
+			items = list
+		}
+		for _, item := range items {
+			formatted, err := FormatParameterValue(item)
+			if err != nil {
+				return err
+			}
+			var isAllowed bool
+			for _, allowed := range param.Allowed {
+				if formatted == allowed {
+					isAllowed = true
+					break
+				}
+			}
+			if !isAllowed {
+				return fmt.Errorf("%s is not allowed, value must be one of: %s", formatted, strings.Join(param.Allowed, ", "))
+			}
+		}
+	}
+	if str, ok := value.(string); ok && param.Regex != "" {
+		re, err := regexp.Compile(param.Regex)
+		if err != nil {
+			// The regex is python flavoured and might not be supported by Go, leave the validation to the API.
+			return nil
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("value must match %s", param.Regex)
+		}
+	}
+	return nil
+}
+
+// FormatParameterValue converts a value of a PaaS parameter (as decoded from JSON)
+// to a string. Lists and dicts are JSON encoded.
+func FormatParameterValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
+
+// EqualParameterValues checks whether a configured string value of a PaaS parameter
+// is equal to the value returned by the API, e.g. "1.0" and 1, or "True" and true.
+func EqualParameterValues(configured string, actual interface{}) bool {
+	switch v := actual.(type) {
+	case string:
+		return configured == v
+	case bool:
+		b, err := strconv.ParseBool(configured)
+		return err == nil && b == v
+	case int:
+		f, err := strconv.ParseFloat(configured, 64)
+		return err == nil && f == float64(v)
+	case float64:
+		f, err := strconv.ParseFloat(configured, 64)
+		return err == nil && f == v
+	case []interface{}, map[string]interface{}:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(configured), &decoded); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(decoded, v)
+	default:
+		formatted, err := FormatParameterValue(v)
+		return err == nil && configured == formatted
+	}
+}
@@ -0,0 +1,82 @@
+package paasu
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func TestParseParameterValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		param    gsclient.Parameter
+		expected interface{}
+		valid    bool
+	}{
+		{"abc", gsclient.Parameter{Type: ParameterTypeString}, "abc", true},
+		{"42", gsclient.Parameter{Type: ParameterTypeInteger}, 42, true},
+		{"4.2", gsclient.Parameter{Type: ParameterTypeInteger}, nil, false},
+		{"4.2", gsclient.Parameter{Type: ParameterTypeFloat}, 4.2, true},
+		{"true", gsclient.Parameter{Type: ParameterTypeBoolean}, true, true},
+		{"yes", gsclient.Parameter{Type: ParameterTypeBoolean}, nil, false},
+		{`["a","b"]`, gsclient.Parameter{Type: ParameterTypeList}, []interface{}{"a", "b"}, true},
+		{`{"a":1}`, gsclient.Parameter{Type: ParameterTypeDict}, map[string]interface{}{"a": float64(1)}, true},
+		{"abc", gsclient.Parameter{Type: "unknown"}, nil, false},
+	}
+	for _, test := range tests {
+		result, err := ParseParameterValue(test.value, test.param)
+		if (err == nil) != test.valid {
+			t.Errorf("ParseParameterValue(%q, %s) error = %v, expected valid = %t", test.value, test.param.Type, err, test.valid)
+			continue
+		}
+		if test.valid && !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("ParseParameterValue(%q, %s) = %#v, expected %#v", test.value, test.param.Type, result, test.expected)
+		}
+	}
+}
+
+func TestValidateParameterValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		param gsclient.Parameter
+		valid bool
+	}{
+		{10, gsclient.Parameter{Min: 1, Max: 100}, true},
+		{0, gsclient.Parameter{Min: 1, Max: 100}, false},
+		{101.5, gsclient.Parameter{Min: 1, Max: 100}, false},
+		{"ROW", gsclient.Parameter{Allowed: []string{"ROW", "MIXED"}}, true},
+		{"STATEMENT", gsclient.Parameter{Allowed: []string{"ROW", "MIXED"}}, false},
+		{"64M", gsclient.Parameter{Regex: "^[0-9]+[KMG]$"}, true},
+		{"64X", gsclient.Parameter{Regex: "^[0-9]+[KMG]$"}, false},
+		{"anything", gsclient.Parameter{Regex: "(?<=a)b"}, true},
+		{[]interface{}{"pg_trgm", "hstore"}, gsclient.Parameter{Allowed: []string{"hstore", "pg_trgm", "uuid-ossp"}}, true},
+		{[]interface{}{"pg_trgm", "postgis"}, gsclient.Parameter{Allowed: []string{"hstore", "pg_trgm", "uuid-ossp"}}, false},
+	}
+	for _, test := range tests {
+		err := ValidateParameterValue(test.value, test.param)
+		if (err == nil) != test.valid {
+			t.Errorf("ValidateParameterValue(%v) error = %v, expected valid = %t", test.value, err, test.valid)
+		}
+	}
+}
+
+func TestEqualParameterValues(t *testing.T) {
+	tests := []struct {
+		configured string
+		actual     interface{}
+		expected   bool
+	}{
+		{"abc", "abc", true},
+		{"1.0", float64(1), true},
+		{"1", 2, false},
+		{"True", true, true},
+		{`["a", "b"]`, []interface{}{"a", "b"}, true},
+		{`{"a": 1}`, map[string]interface{}{"a": float64(2)}, false},
+	}
+	for _, test := range tests {
+		if result := EqualParameterValues(test.configured, test.actual); result != test.expected {
+			t.Errorf("EqualParameterValues(%q, %v) = %t, expected %t", test.configured, test.actual, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package paasu
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// splitRelease splits a release (e.g. "5.7", "13", "1.21-gs1") into its numeric
+// and non-numeric segments.
+func splitRelease(release string) []string {
+	var segments []string
+	var current strings.Builder
+	var currentIsDigit bool
+	for _, r := range release {
+		isDigit := unicode.IsDigit(r)
+		if !isDigit && !unicode.IsLetter(r) {
+			// separators (".", "-", "_", ...) only end a segment
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		if current.Len() > 0 && isDigit != currentIsDigit {
+			segments = append(segments, current.String())
+			current.Reset()
+		}
+		currentIsDigit = isDigit
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		segments = append(segments, current.String())
+	}
+	return segments
+}
+
+// CompareReleases compares two PaaS releases (or versions) segment by segment.
+// Numeric segments are compared numerically, so that "5.10" is newer than "5.9".
+// It returns -1 if a is older than b, 1 if a is newer than b, and 0 if both are equal.
+func CompareReleases(a, b string) int {
+	aSegments := splitRelease(a)
+	bSegments := splitRelease(b)
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		aNum, aErr := strconv.Atoi(aSegments[i])
+		bNum, bErr := strconv.Atoi(bSegments[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			// a numeric segment is newer than a suffix like "beta"
+			return 1
+		case bErr == nil:
+			return -1
+		default:
+			if c := strings.Compare(aSegments[i], bSegments[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	// The longer release is newer, unless its next segment is a suffix
+	// like "beta" ("1.0.1" > "1.0" > "1.0beta").
+	switch {
+	case len(aSegments) < len(bSegments):
+		if _, err := strconv.Atoi(bSegments[len(aSegments)]); err != nil {
+			return 1
+		}
+		return -1
+	case len(aSegments) > len(bSegments):
+		if _, err := strconv.Atoi(aSegments[len(bSegments)]); err != nil {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// LatestRelease returns the newest release of the list, or an empty string if the list is empty.
+func LatestRelease(releases []string) string {
+	var latest string
+	for _, release := range releases {
+		if latest == "" || CompareReleases(release, latest) > 0 {
+			latest = release
+		}
+	}
+	return latest
+}
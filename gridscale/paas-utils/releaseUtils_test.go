@@ -0,0 +1,43 @@
+package paasu
+
+import "testing"
+
+func TestCompareReleases(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"13", "13", 0},
+		{"12", "13", -1},
+		{"5.10", "5.9", 1},
+		{"1.21", "1.21.3", -1},
+		{"1.21.3", "1.21", 1},
+		{"8.0", "5.7", 1},
+		{"2019", "2017", 1},
+		{"6.0-gs1", "6.0-gs2", -1},
+		{"1.0", "1.0beta", 1},
+	}
+	for _, test := range tests {
+		if result := CompareReleases(test.a, test.b); result != test.expected {
+			t.Errorf("CompareReleases(%q, %q) = %d, expected %d", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	tests := []struct {
+		releases []string
+		expected string
+	}{
+		{[]string{"5.7", "8.0", "5.10"}, "8.0"},
+		{[]string{"1.19", "1.21", "1.20"}, "1.21"},
+		{[]string{"11"}, "11"},
+		{nil, ""},
+	}
+	for _, test := range tests {
+		if result := LatestRelease(test.releases); result != test.expected {
+			t.Errorf("LatestRelease(%v) = %q, expected %q", test.releases, result, test.expected)
+		}
+	}
+}
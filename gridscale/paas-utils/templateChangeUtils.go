@@ -0,0 +1,56 @@
+package paasu
+
+import "sort"
+
+// Kinds of changes of the service template of a PaaS service
+const (
+	TemplateChangeNone                   = "none"
+	TemplateChangePatchUpdate            = "patch_update"
+	TemplateChangeVersionUpgrade         = "version_upgrade"
+	TemplateChangePerformanceClassUpdate = "performance_class_update"
+	TemplateChangeReplace                = "replace"
+)
+
+// ClassifyTemplateChange returns how a PaaS service can be moved from its current template
+// to the target template, based on the update paths of the current template.
+// It returns TemplateChangeReplace if the target template is not reachable in-place.
+func ClassifyTemplateChange(currentUUID, targetUUID string, patchUpdates, versionUpgrades, performanceClassUpdates []string) string {
+	if currentUUID == targetUUID {
+		return TemplateChangeNone
+	}
+	if containsString(patchUpdates, targetUUID) {
+		return TemplateChangePatchUpdate
+	}
+	if containsString(versionUpgrades, targetUUID) {
+		return TemplateChangeVersionUpgrade
+	}
+	if containsString(performanceClassUpdates, targetUUID) {
+		return TemplateChangePerformanceClassUpdate
+	}
+	return TemplateChangeReplace
+}
+
+// InPlaceTemplateChanges returns the sorted descriptions of the templates which are reachable
+// in-place from the current template through any of the given update paths. Templates without
+// a description (e.g. not available anymore) are skipped.
+func InPlaceTemplateChanges(descriptions map[string]string, updatePaths ...[]string) []string {
+	var targets []string
+	for _, uuids := range updatePaths {
+		for _, uuid := range uuids {
+			if target, ok := descriptions[uuid]; ok && !containsString(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
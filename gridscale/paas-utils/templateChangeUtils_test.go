@@ -0,0 +1,55 @@
+package paasu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyTemplateChange(t *testing.T) {
+	patchUpdates := []string{"patch"}
+	versionUpgrades := []string{"upgrade", "both"}
+	performanceClassUpdates := []string{"perf", "both"}
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"current", TemplateChangeNone},
+		{"patch", TemplateChangePatchUpdate},
+		{"upgrade", TemplateChangeVersionUpgrade},
+		{"perf", TemplateChangePerformanceClassUpdate},
+		{"both", TemplateChangeVersionUpgrade},
+		{"downgrade", TemplateChangeReplace},
+	}
+	for _, test := range tests {
+		result := ClassifyTemplateChange("current", test.target, patchUpdates, versionUpgrades, performanceClassUpdates)
+		if result != test.expected {
+			t.Errorf("ClassifyTemplateChange(%q) = %q, expected %q", test.target, result, test.expected)
+		}
+	}
+}
+
+func TestInPlaceTemplateChanges(t *testing.T) {
+	descriptions := map[string]string{
+		"patch":   "release 14.2 with performance class standard",
+		"upgrade": "release 15 with performance class standard",
+		"perf":    "release 14 with performance class high",
+	}
+	tests := []struct {
+		updatePaths [][]string
+		expected    []string
+	}{
+		{nil, nil},
+		{[][]string{{"unknown"}}, nil},
+		{[][]string{{"patch"}, {"upgrade", "perf"}, {"perf", "unknown"}}, []string{
+			"release 14 with performance class high",
+			"release 14.2 with performance class standard",
+			"release 15 with performance class standard",
+		}},
+	}
+	for _, test := range tests {
+		result := InPlaceTemplateChanges(descriptions, test.updatePaths...)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("InPlaceTemplateChanges(%v) = %v, expected %v", test.updatePaths, result, test.expected)
+		}
+	}
+}
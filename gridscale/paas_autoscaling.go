@@ -0,0 +1,139 @@
+package gridscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	paasu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/paas-utils"
+)
+
+// Names of the PaaS resource limits which cap the autoscaling of a service
+const (
+	paasCoresResourceLimit   = "cores"
+	paasStorageResourceLimit = "storage"
+)
+
+// addPaaSAutoscalingSchema adds the `autoscaling` block to the schema of a PaaS resource.
+// If the resource has the legacy `max_core_count` attribute, it is deprecated in favour of the block.
+func addPaaSAutoscalingSchema(s map[string]*schema.Schema) {
+	s["autoscaling"] = &schema.Schema{
+		Type: schema.TypeList,
+		Description: `Autoscaling of the service. The service scales between the lower bounds of the service template
+		and the configured maximums. The current size of the service is not part of the state, so scaling does not cause a diff.`,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cores_min": {
+					Type:        schema.TypeInt,
+					Description: "Minimum CPU core count, defined by the service template.",
+					Computed:    true,
+				},
+				"cores_max": {
+					Type:         schema.TypeInt,
+					Description:  "Maximum CPU core count. It must be within the core autoscaling bounds of the service template.",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"storage_min": {
+					Type:        schema.TypeInt,
+					Description: "Minimum storage size (GB), defined by the service template.",
+					Computed:    true,
+				},
+				"storage_max": {
+					Type:         schema.TypeInt,
+					Description:  "Maximum storage size (GB). It must be within the storage autoscaling bounds of the service template.",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+	if maxCoreCount, ok := s["max_core_count"]; ok {
+		maxCoreCount.Deprecated = "Use autoscaling.cores_max instead."
+		maxCoreCount.ConflictsWith = []string{"autoscaling"}
+	}
+}
+
+// validatePaaSAutoscaling validates the configured autoscaling maximums against the
+// autoscaling bounds of the chosen service template.
+func validatePaaSAutoscaling(d *schema.ResourceDiff, template gsclient.PaaSTemplate) error {
+	templateChanged := d.Id() == "" || d.HasChange("release") || d.HasChange("performance_class")
+	bounds := template.Properties.Autoscaling
+	var errorMessages []string
+	for _, limit := range []struct {
+		key    string
+		bounds gsclient.AutoscalingResourceProperties
+	}{
+		{"autoscaling.0.cores_max", bounds.Cores},
+		{"autoscaling.0.storage_max", bounds.Storage},
+	} {
+		value, ok := d.GetOk(limit.key)
+		if !ok || !d.NewValueKnown(limit.key) || (!templateChanged && !d.HasChange(limit.key)) {
+			continue
+		}
+		if err := paasu.ValidateAutoscalingLimit(value.(int), limit.bounds.Min, limit.bounds.Max); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Invalid '%s' value: %v\n", limit.key, err))
+		}
+	}
+	if len(errorMessages) != 0 {
+		return errors.New(strings.Join(errorMessages, ""))
+	}
+	return nil
+}
+
+// expandPaaSAutoscalingLimits returns the resource limits of a PaaS service from its `autoscaling` block.
+// If hasMaxCoreCount is true, a changed legacy `max_core_count` takes precedence over `autoscaling.cores_max`.
+func expandPaaSAutoscalingLimits(d *schema.ResourceData, hasMaxCoreCount bool) []gsclient.ResourceLimit {
+	coresMax := d.Get("autoscaling.0.cores_max").(int)
+	if hasMaxCoreCount && (coresMax == 0 || d.HasChange("max_core_count")) {
+		if val, ok := d.GetOk("max_core_count"); ok {
+			coresMax = val.(int)
+		}
+	}
+	var limits []gsclient.ResourceLimit
+	if coresMax > 0 {
+		limits = append(limits, gsclient.ResourceLimit{
+			Resource: paasCoresResourceLimit,
+			Limit:    coresMax,
+		})
+	}
+	if storageMax := d.Get("autoscaling.0.storage_max").(int); storageMax > 0 {
+		limits = append(limits, gsclient.ResourceLimit{
+			Resource: paasStorageResourceLimit,
+			Limit:    storageMax,
+		})
+	}
+	return limits
+}
+
+// setPaaSAutoscaling sets the `autoscaling` block from the resource limits of a PaaS service
+// and the autoscaling bounds of its service template.
+func setPaaSAutoscaling(client *gsclient.Client, d *schema.ResourceData, props gsclient.PaaSServiceProperties) error {
+	autoscaling := make(map[string]interface{})
+	for _, value := range props.ResourceLimits {
+		switch value.Resource {
+		case paasCoresResourceLimit:
+			autoscaling["cores_max"] = value.Limit
+		case paasStorageResourceLimit:
+			autoscaling["storage_max"] = value.Limit
+		}
+	}
+	template, err := getPaaSTemplateProperties(context.Background(), client, props.ServiceTemplateUUID)
+	if err != nil {
+		return err
+	}
+	if template != nil {
+		autoscaling["cores_min"] = template.Autoscaling.Cores.Min
+		autoscaling["storage_min"] = template.Autoscaling.Storage.Min
+	}
+	return d.Set("autoscaling", []interface{}{autoscaling})
+}
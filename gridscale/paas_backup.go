@@ -0,0 +1,279 @@
+package gridscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	paasu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/paas-utils"
+)
+
+// paasBackupParameters are the PaaS parameters (and attributes of the `s3_backup` block)
+// which configure the backups of a service, as in gridscale_sqlserver.
+var paasBackupParameters = []string{
+	"backup_bucket",
+	"backup_retention",
+	"backup_access_key",
+	"backup_secret_key",
+	"backup_server_url",
+	"backup_schedule_time",
+}
+
+// paasRestoreParameters maps the attributes of the `restore` block to the PaaS parameters of the service.
+// They are only sent when the service is created.
+var paasRestoreParameters = map[string]string{
+	"source_service_uuid": "restore_service_uuid",
+	"backup_name":         "restore_backup_name",
+	"point_in_time":       "restore_point_in_time",
+}
+
+// addPaaSBackupSchema adds the `s3_backup` and `restore` blocks to the schema of a database PaaS resource.
+func addPaaSBackupSchema(s map[string]*schema.Schema) {
+	s["s3_backup"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Backups of the service to an Object Storage bucket.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"backup_bucket": {
+					Type:         schema.TypeString,
+					Description:  "Object Storage bucket to upload backups to.",
+					Required:     true,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				"backup_retention": {
+					Type:         schema.TypeInt,
+					Description:  "Retention (in seconds) for local originals of backups. (0 for immediate removal once uploaded to Object Storage (default), higher values for delayed removal after the given time and once uploaded to Object Storage).",
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"backup_access_key": {
+					Type:         schema.TypeString,
+					Description:  "Access key used to authenticate against Object Storage server.",
+					Required:     true,
+					Sensitive:    true,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				"backup_secret_key": {
+					Type:         schema.TypeString,
+					Description:  "Secret key used to authenticate against Object Storage server.",
+					Required:     true,
+					Sensitive:    true,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				"backup_server_url": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  defaultBackupServerURL,
+					ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+						if v.(string) != defaultBackupServerURL {
+							errors = append(errors, fmt.Errorf("Currently, only %s is supported", defaultBackupServerURL))
+						}
+						return
+					},
+					Description: "Object Storage server URL the bucket is located on.",
+				},
+				"backup_schedule_time": {
+					Type:         schema.TypeString,
+					Description:  "Time of the day (UTC, format: HH:MM) the daily backup is taken. Default: the default of the service template.",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validateClockTime,
+				},
+			},
+		},
+	}
+	s["restore"] = &schema.Schema{
+		Type:         schema.TypeList,
+		Description:  "Create the service from a backup of another service of the same flavour, or from a point in time of its backups. The backups are read from the `s3_backup` bucket.",
+		Optional:     true,
+		ForceNew:     true,
+		MaxItems:     1,
+		RequiredWith: []string{"s3_backup"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_service_uuid": {
+					Type:         schema.TypeString,
+					Description:  "UUID of the (possibly deleted) service whose backups are restored.",
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IsUUID,
+				},
+				"backup_name": {
+					Type:         schema.TypeString,
+					Description:  "Name of the backup which is restored.",
+					Optional:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.NoZeroValues,
+					ExactlyOneOf: []string{"restore.0.backup_name", "restore.0.point_in_time"},
+				},
+				"point_in_time": {
+					Type:         schema.TypeString,
+					Description:  fmt.Sprintf("Point in time (UTC, format: %q) the service is recovered to.", timeLayout),
+					Optional:     true,
+					ForceNew:     true,
+					ValidateFunc: validateTimeLayout,
+					ExactlyOneOf: []string{"restore.0.backup_name", "restore.0.point_in_time"},
+				},
+			},
+		},
+	}
+}
+
+func validateClockTime(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse("15:04", v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s is not a valid time of the day (format: HH:MM): %v", k, err))
+	}
+	return
+}
+
+// validatePaaSBackup validates the `s3_backup` and `restore` blocks against the parameters schema of
+// the chosen template. A restore is only allowed from a service of the same flavour with a release
+// which is not newer than the chosen release.
+func validatePaaSBackup(ctx context.Context, d *schema.ResourceDiff, client *gsclient.Client, template gsclient.PaaSTemplate) error {
+	props := template.Properties
+	var errorMessages []string
+	validateParameter := func(key, param string) {
+		value, ok := d.GetOk(key)
+		if !ok || !d.NewValueKnown(key) {
+			return
+		}
+		scheme, ok := props.ParametersSchema[param]
+		if !ok {
+			errorMessages = append(errorMessages, fmt.Sprintf("'%s' is not supported by %s release %s\n", key, props.Flavour, props.Release))
+			return
+		}
+		if err := paasu.ValidateParameterValue(value, scheme); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Invalid '%s' value. %v\n", key, err))
+		}
+	}
+	if _, ok := d.GetOk("s3_backup"); ok {
+		for _, param := range paasBackupParameters {
+			// the schedule time is computed from the template default if it is not configured
+			if param == "backup_schedule_time" && !d.HasChange("s3_backup.0.backup_schedule_time") {
+				continue
+			}
+			validateParameter(fmt.Sprintf("s3_backup.0.%s", param), param)
+		}
+	}
+	// The restore parameters are only sent when the service is created (or replaced).
+	if _, ok := d.GetOk("restore"); ok && (d.Id() == "" || d.HasChange("restore")) {
+		for attr, param := range paasRestoreParameters {
+			validateParameter(fmt.Sprintf("restore.0.%s", attr), param)
+		}
+		if err := validatePaaSRestoreSource(ctx, d, client, props); err != nil {
+			errorMessages = append(errorMessages, err.Error()+"\n")
+		}
+	}
+	if len(errorMessages) != 0 {
+		return errors.New(strings.Join(errorMessages, ""))
+	}
+	return nil
+}
+
+// validatePaaSRestoreSource checks that the source service of a restore is compatible with the chosen template.
+func validatePaaSRestoreSource(ctx context.Context, d *schema.ResourceDiff, client *gsclient.Client, props gsclient.PaaSTemplateProperties) error {
+	if !d.NewValueKnown("restore.0.source_service_uuid") {
+		return nil
+	}
+	sourceUUID := d.Get("restore.0.source_service_uuid").(string)
+	source, err := getPaaSServiceIncludingDeleted(ctx, client, sourceUUID)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return fmt.Errorf("source service %s of the restore does not exist", sourceUUID)
+	}
+	sourceTemplate, err := getPaaSTemplateProperties(ctx, client, source.ServiceTemplateUUID)
+	if err != nil {
+		return err
+	}
+	if sourceTemplate == nil {
+		return fmt.Errorf("template %s of the source service %s of the restore does not exist", source.ServiceTemplateUUID, sourceUUID)
+	}
+	if sourceTemplate.Flavour != props.Flavour {
+		return fmt.Errorf("backups of the %s service %s cannot be restored to a %s service", sourceTemplate.Flavour, sourceUUID, props.Flavour)
+	}
+	if paasu.CompareReleases(sourceTemplate.Release, props.Release) > 0 {
+		return fmt.Errorf("backups of release %s (service %s) cannot be restored to the older release %s", sourceTemplate.Release, sourceUUID, props.Release)
+	}
+	if pointInTime, ok := d.GetOk("restore.0.point_in_time"); ok && d.NewValueKnown("restore.0.point_in_time") {
+		recoveryTime, err := time.Parse(timeLayout, pointInTime.(string))
+		if err != nil {
+			return err
+		}
+		if recoveryTime.After(time.Now().UTC()) {
+			return fmt.Errorf("point_in_time %s of the restore is in the future", pointInTime)
+		}
+		if recoveryTime.Before(source.CreateTime.Time) {
+			return fmt.Errorf("point_in_time %s of the restore is before the source service %s was created (%s)",
+				pointInTime, sourceUUID, source.CreateTime.UTC().Format(timeLayout))
+		}
+	}
+	return nil
+}
+
+// getPaaSServiceIncludingDeleted returns the properties of a PaaS service, which might have been deleted,
+// or nil if the service does not exist.
+func getPaaSServiceIncludingDeleted(ctx context.Context, client *gsclient.Client, id string) (*gsclient.PaaSServiceProperties, error) {
+	service, err := client.GetPaaSService(ctx, id)
+	if err == nil {
+		return &service.Properties, nil
+	}
+	if requestError, ok := err.(gsclient.RequestError); !ok || requestError.StatusCode != 404 {
+		return nil, err
+	}
+	deletedServices, err := client.GetDeletedPaaSServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, deletedService := range deletedServices {
+		if deletedService.Properties.ObjectUUID == id {
+			props := deletedService.Properties
+			return &props, nil
+		}
+	}
+	return nil, nil
+}
+
+// expandPaaSBackupParameters adds the parameters of the `s3_backup` block and, if withRestore is true,
+// the parameters of the `restore` block to params.
+func expandPaaSBackupParameters(d *schema.ResourceData, params map[string]interface{}, withRestore bool) {
+	if _, ok := d.GetOk("s3_backup"); !ok {
+		return
+	}
+	for _, param := range paasBackupParameters {
+		if value, ok := d.GetOk(fmt.Sprintf("s3_backup.0.%s", param)); ok {
+			params[param] = value
+		}
+	}
+	if _, ok := d.GetOk("restore"); !ok || !withRestore {
+		return
+	}
+	for attr, param := range paasRestoreParameters {
+		if value, ok := d.GetOk(fmt.Sprintf("restore.0.%s", attr)); ok {
+			params[param] = value
+		}
+	}
+}
+
+// setPaaSBackup sets the `s3_backup` block from the parameters of a PaaS service.
+func setPaaSBackup(d *schema.ResourceData, props gsclient.PaaSServiceProperties) error {
+	if props.Parameters["backup_bucket"] == nil {
+		return d.Set("s3_backup", nil)
+	}
+	backup := make(map[string]interface{})
+	for _, param := range paasBackupParameters {
+		if value, ok := props.Parameters[param]; ok {
+			backup[param] = value
+		}
+	}
+	return d.Set("s3_backup", []interface{}{backup})
+}
@@ -0,0 +1,70 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// addPaaSCredentialRotation adds the `rotate_credentials` trigger to a PaaS resource. When the trigger
+// changes, the credential attributes (credentialKeys) of the resource are unknown until the credentials
+// have been renewed, so that dependent resources get the new values in the same apply.
+func addPaaSCredentialRotation(resource *schema.Resource, credentialKeys ...string) {
+	resource.Schema["rotate_credentials"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Change this value (e.g. to the current date) to renew the credentials of the service.",
+		Optional:    true,
+	}
+	customizeCredentialsDiff := func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" || !d.HasChange("rotate_credentials") {
+			return nil
+		}
+		for _, key := range credentialKeys {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if resource.CustomizeDiff == nil {
+		resource.CustomizeDiff = customizeCredentialsDiff
+		return
+	}
+	resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff, customizeCredentialsDiff)
+}
+
+// renewPaaSCredentials renews the credentials of a PaaS service and waits until
+// the service is active again with the new credentials.
+func renewPaaSCredentials(ctx context.Context, client *gsclient.Client, id string) error {
+	paas, err := client.GetPaaSService(ctx, id)
+	if err != nil {
+		return err
+	}
+	oldCredentials := fmt.Sprintf("%v", paas.Properties.Credentials)
+	// Despite its name, RenewK8sCredentials renews the credentials of any PaaS service.
+	if err = client.RenewK8sCredentials(ctx, id); err != nil {
+		return fmt.Errorf("error renewing credentials: %v", err)
+	}
+	for {
+		paas, err = client.GetPaaSService(ctx, id)
+		if err != nil {
+			return err
+		}
+		props := paas.Properties
+		credentialsRenewed := len(props.Credentials) == 0 || fmt.Sprintf("%v", props.Credentials) != oldCredentials
+		if props.Status == "active" && credentialsRenewed {
+			return nil
+		}
+		log.Printf("[DEBUG] Waiting for new credentials of PaaS service (%s) to be active, current status: %s", id, props.Status)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for new credentials of PaaS service (%s) to be active: %v", id, ctx.Err())
+		case <-time.After(client.DelayInterval()):
+		}
+	}
+}
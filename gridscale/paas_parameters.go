@@ -0,0 +1,275 @@
+package gridscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	paasu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/paas-utils"
+)
+
+// getPaaSTemplateProperties returns the properties of the PaaS template with the given UUID,
+// or nil if the template does not exist.
+func getPaaSTemplateProperties(ctx context.Context, client *gsclient.Client, templateUUID string) (*gsclient.PaaSTemplateProperties, error) {
+	paasTemplates, err := client.GetPaaSTemplateList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range paasTemplates {
+		if template.Properties.ObjectUUID == templateUUID {
+			props := template.Properties
+			return &props, nil
+		}
+	}
+	return nil, nil
+}
+
+// parsePaaSParameters converts the string values of PaaS parameters to the types defined
+// in the parameters schema of the PaaS template, and validates them against the schema.
+func parsePaaSParameters(parametersSchema map[string]gsclient.Parameter, values map[string]interface{}) (map[string]interface{}, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errorMessages []string
+	params := make(map[string]interface{})
+	for _, name := range names {
+		param, ok := parametersSchema[name]
+		if !ok {
+			validNames := make([]string, 0, len(parametersSchema))
+			for validName := range parametersSchema {
+				validNames = append(validNames, validName)
+			}
+			sort.Strings(validNames)
+			errorMessages = append(errorMessages, fmt.Sprintf("'%s' is not a valid parameter. Valid parameters are: %s", name, strings.Join(validNames, ", ")))
+			continue
+		}
+		value, err := paasu.ParseParameterValue(values[name].(string), param)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Invalid '%s' value. Value must be of type %s: %v", name, param.Type, err))
+			continue
+		}
+		if err = paasu.ValidateParameterValue(value, param); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Invalid '%s' value: %v", name, err))
+			continue
+		}
+		params[name] = value
+	}
+	if len(errorMessages) > 0 {
+		return nil, fmt.Errorf("invalid parameters:\n\t%s", strings.Join(errorMessages, "\n\t"))
+	}
+	return params, nil
+}
+
+// customizePaaSParametersDiff validates the `parameters` of a gridscale_paas resource against the
+// parameters schema of its template, fills the defaults of the schema into `parameters_computed`,
+// and forces a new service if an immutable parameter changes.
+func customizePaaSParametersDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("service_template_uuid") || !d.NewValueKnown("parameters") {
+		return nil
+	}
+	configured := d.Get("parameters").(map[string]interface{})
+	if len(configured) == 0 && !d.HasChange("parameters") {
+		return nil
+	}
+	client := meta.(*gsclient.Client)
+	template, err := getPaaSTemplateProperties(ctx, client, d.Get("service_template_uuid").(string))
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		// Unknown templates are reported by the API
+		return nil
+	}
+	if _, err = parsePaaSParameters(template.ParametersSchema, configured); err != nil {
+		return err
+	}
+	if d.Id() != "" && !d.HasChange("parameters") && !d.HasChange("service_template_uuid") {
+		return nil
+	}
+
+	// Effective parameters: the defaults of the schema, the parameters which are
+	// not part of the schema (managed by the service), and the configured parameters.
+	oldEffective := d.Get("parameters_computed").(map[string]interface{})
+	effective := make(map[string]interface{})
+	for name, value := range oldEffective {
+		if _, ok := template.ParametersSchema[name]; !ok {
+			effective[name] = value
+		}
+	}
+	for name, param := range template.ParametersSchema {
+		if param.Default == nil {
+			continue
+		}
+		defaultValue, err := paasu.FormatParameterValue(param.Default)
+		if err != nil {
+			return err
+		}
+		effective[name] = defaultValue
+	}
+	for name, value := range configured {
+		effective[name] = value
+	}
+	if err = d.SetNew("parameters_computed", effective); err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+	for name, param := range template.ParametersSchema {
+		if !param.Immutable {
+			continue
+		}
+		oldValue, hasOld := oldEffective[name]
+		newValue, hasNew := effective[name]
+		if !hasOld && !hasNew {
+			continue
+		}
+		// Adding or removing an immutable parameter is a change as well
+		changed := hasOld != hasNew
+		if !changed {
+			oldTyped, oldErr := paasu.ParseParameterValue(oldValue.(string), param)
+			newTyped, newErr := paasu.ParseParameterValue(newValue.(string), param)
+			changed = oldErr != nil || newErr != nil || !reflect.DeepEqual(oldTyped, newTyped)
+		}
+		if changed {
+			// ForceNew fails on keys without a change, mark the key which caused the new value.
+			if d.HasChange("parameters") {
+				return d.ForceNew("parameters")
+			}
+			if d.HasChange("service_template_uuid") {
+				return d.ForceNew("service_template_uuid")
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// expandPaaSServiceParameters returns the typed parameters of a gridscale_paas resource,
+// either from the `parameters` map or from the deprecated `parameter` blocks.
+func expandPaaSServiceParameters(ctx context.Context, client *gsclient.Client, d *schema.ResourceData) (map[string]interface{}, error) {
+	if configured := d.Get("parameters").(map[string]interface{}); len(configured) > 0 {
+		templateUUID := d.Get("service_template_uuid").(string)
+		template, err := getPaaSTemplateProperties(ctx, client, templateUUID)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			return nil, fmt.Errorf("PaaS template %s not found", templateUUID)
+		}
+		return parsePaaSParameters(template.ParametersSchema, configured)
+	}
+
+	params := make(map[string]interface{}, 0)
+	for _, value := range d.Get("parameter").(*schema.Set).List() {
+		mapVal := value.(map[string]interface{})
+		var param string
+		var val interface{}
+		param = mapVal["param"].(string)
+		paramValType := mapVal["type"].(string)
+		typedVal, err := convStrToTypeInterface(paramValType, mapVal["value"].(string))
+		if err != nil {
+			return nil, err
+		}
+		val = typedVal
+		params[param] = val
+	}
+	return params, nil
+}
+
+// setPaaSParameterAttributes sets the attributes (keys) which expose the PaaS parameters with the same name.
+func setPaaSParameterAttributes(d *schema.ResourceData, props gsclient.PaaSServiceProperties, keys []string) error {
+	for _, key := range keys {
+		value, ok := props.Parameters[key]
+		if !ok {
+			continue
+		}
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("error setting %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// paasParameterAttributeGetter is implemented by schema.ResourceData and schema.ResourceDiff.
+type paasParameterAttributeGetter interface {
+	GetOk(key string) (interface{}, bool)
+	GetRawConfig() cty.Value
+}
+
+// getPaaSParameterAttribute works like GetOk, but it also returns zero values (e.g. false)
+// which are set in the configuration, so that parameters can be turned off.
+func getPaaSParameterAttribute(d paasParameterAttributeGetter, key string) (interface{}, bool) {
+	value, ok := d.GetOk(key)
+	if ok {
+		return value, true
+	}
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().IsObjectType() || !rawConfig.Type().HasAttribute(key) {
+		return value, false
+	}
+	return value, !rawConfig.GetAttr(key).IsNull()
+}
+
+// expandPaaSParameterAttributes returns the PaaS parameters of the attributes (keys) which are set.
+func expandPaaSParameterAttributes(d *schema.ResourceData, keys []string) map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, key := range keys {
+		value, ok := getPaaSParameterAttribute(d, key)
+		if !ok {
+			continue
+		}
+		if set, isSet := value.(*schema.Set); isSet {
+			value = convSOStrings(set.List())
+		}
+		params[key] = value
+	}
+	return params
+}
+
+// validatePaaSParameterAttributes validates the attributes (keys) which expose PaaS parameters against
+// the parameters schema of the template. Changes of parameters which are immutable replace the service.
+func validatePaaSParameterAttributes(d *schema.ResourceDiff, template gsclient.PaaSTemplate, keys []string) error {
+	var errorMessages []string
+	for _, key := range keys {
+		value, ok := getPaaSParameterAttribute(d, key)
+		if !ok || !d.NewValueKnown(key) {
+			continue
+		}
+		scheme, ok := template.Properties.ParametersSchema[key]
+		if !ok {
+			// Values which are only read from the service are not validated
+			if d.Id() != "" && !d.HasChange(key) {
+				continue
+			}
+			errorMessages = append(errorMessages, fmt.Sprintf("'%s' is not supported by %s release %s\n", key, template.Properties.Flavour, template.Properties.Release))
+			continue
+		}
+		if set, isSet := value.(*schema.Set); isSet {
+			value = set.List()
+		}
+		if err := paasu.ValidateParameterValue(value, scheme); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("Invalid '%s' value. %v\n", key, err))
+			continue
+		}
+		if scheme.Immutable && d.Id() != "" && d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	if len(errorMessages) != 0 {
+		return errors.New(strings.Join(errorMessages, ""))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package gridscale
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+	sqlu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/sql-utils"
+)
+
+var postgreSQLSSLModes = []string{"disable", "require", "verify-ca", "verify-full"}
+var mySQLTLSModes = []string{"true", "false", "skip-verify", "preferred"}
+
+// getSQLConnectionSchema returns the `service` block of the resources which manage the
+// objects inside of a PostgreSQL or MySQL service. extra adds flavour specific attributes.
+func getSQLConnectionSchema(flavour string, extra map[string]*schema.Schema) *schema.Schema {
+	connectionSchema := map[string]*schema.Schema{
+		"host": {
+			Type:         schema.TypeString,
+			Description:  fmt.Sprintf("Host of the %s service, e.g. the host of its listen_port.", flavour),
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Description:  fmt.Sprintf("Port of the %s service, e.g. the port of its listen_port.", flavour),
+			Required:     true,
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"username": {
+			Type:         schema.TypeString,
+			Description:  fmt.Sprintf("Username of the administrative user of the %s service.", flavour),
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+		"password": {
+			Type:         schema.TypeString,
+			Description:  fmt.Sprintf("Password of the administrative user of the %s service.", flavour),
+			Required:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+	}
+	for key, value := range extra {
+		connectionSchema[key] = value
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: fmt.Sprintf("Connection to the %s service, e.g. to a gridscale_%s resource.", flavour, strings.ToLower(flavour)),
+		Required:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: connectionSchema,
+		},
+	}
+}
+
+// getPostgreSQLConnectionSchema returns the `service` block of the PostgreSQL resources.
+func getPostgreSQLConnectionSchema() *schema.Schema {
+	return getSQLConnectionSchema("PostgreSQL", map[string]*schema.Schema{
+		"database": {
+			Type:         schema.TypeString,
+			Description:  "Database to connect to.",
+			Optional:     true,
+			Default:      "postgres",
+			ValidateFunc: validation.NoZeroValues,
+		},
+		"sslmode": {
+			Type:         schema.TypeString,
+			Description:  "SSL mode of the connection.",
+			Optional:     true,
+			Default:      "require",
+			ValidateFunc: validation.StringInSlice(postgreSQLSSLModes, false),
+		},
+	})
+}
+
+// getMySQLConnectionSchema returns the `service` block of the MySQL resources.
+func getMySQLConnectionSchema() *schema.Schema {
+	return getSQLConnectionSchema("MySQL", map[string]*schema.Schema{
+		"tls": {
+			Type:         schema.TypeString,
+			Description:  "TLS mode of the connection.",
+			Optional:     true,
+			Default:      "preferred",
+			ValidateFunc: validation.StringInSlice(mySQLTLSModes, false),
+		},
+	})
+}
+
+// openPostgreSQLConnection connects to the PostgreSQL service of the `service` block.
+// If database is not empty, it is used instead of the database of the `service` block.
+func openPostgreSQLConnection(d *schema.ResourceData, database string) (*sql.DB, error) {
+	if database == "" {
+		database = d.Get("service.0.database").(string)
+	}
+	connStr := sqlu.PostgreSQLConnectionString(
+		d.Get("service.0.host").(string),
+		d.Get("service.0.port").(int),
+		d.Get("service.0.username").(string),
+		d.Get("service.0.password").(string),
+		database,
+		d.Get("service.0.sslmode").(string),
+	)
+	connector, err := pq.NewConnector(connStr)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	// Every operation of a resource only needs a single connection
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// openMySQLConnection connects to the MySQL service of the `service` block.
+// Query parameters are interpolated by the driver, so that they can be used in statements
+// which cannot be prepared (e.g. CREATE USER).
+func openMySQLConnection(d *schema.ResourceData) (*sql.DB, error) {
+	config := mysql.NewConfig()
+	config.Net = "tcp"
+	config.Addr = net.JoinHostPort(d.Get("service.0.host").(string), strconv.Itoa(d.Get("service.0.port").(int)))
+	config.User = d.Get("service.0.username").(string)
+	config.Passwd = d.Get("service.0.password").(string)
+	config.TLSConfig = d.Get("service.0.tls").(string)
+	config.InterpolateParams = true
+	connector, err := mysql.NewConnector(config)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
@@ -0,0 +1,95 @@
+package gridscale
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	paasu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/paas-utils"
+)
+
+// addPaaSTemplateChangeSchema adds the attributes which control and show how a change
+// of `release` or `performance_class` is applied to a PaaS service.
+func addPaaSTemplateChangeSchema(s map[string]*schema.Schema) {
+	s["allow_upgrade_by_replace"] = &schema.Schema{
+		Type: schema.TypeBool,
+		Description: `Allow changes of release or performance class which cannot be applied in-place
+		(e.g. downgrades) by destroying the service and creating a new one. All data of the service is lost.`,
+		Optional: true,
+		Default:  false,
+	}
+	s["template_change"] = &schema.Schema{
+		Type: schema.TypeString,
+		Description: fmt.Sprintf("How the last change of release or performance class is applied: %s.", strings.Join([]string{
+			paasu.TemplateChangePatchUpdate,
+			paasu.TemplateChangeVersionUpgrade,
+			paasu.TemplateChangePerformanceClassUpdate,
+			paasu.TemplateChangeReplace,
+		}, ", ")),
+		Computed: true,
+	}
+}
+
+// customizePaaSTemplateChangeDiff classifies a change of the service template (caused by a change
+// of `release` or `performance_class`) of an existing PaaS service, using the update paths of its
+// current template. The target template is shown in the plan. Changes which cannot be applied
+// in-place are rejected, unless `allow_upgrade_by_replace` is set.
+func customizePaaSTemplateChangeDiff(d *schema.ResourceDiff, paasTemplates []gsclient.PaaSTemplate, chosenTemplate gsclient.PaaSTemplate) error {
+	if d.Id() == "" || (!d.HasChange("release") && !d.HasChange("performance_class")) {
+		return nil
+	}
+	if !d.NewValueKnown("release") || !d.NewValueKnown("performance_class") {
+		return nil
+	}
+	currentUUID, _ := d.GetChange("service_template_uuid")
+	var currentTemplate *gsclient.PaaSTemplateProperties
+	descriptions := make(map[string]string)
+	for _, template := range paasTemplates {
+		descriptions[template.Properties.ObjectUUID] = fmt.Sprintf("release %s with performance class %s", template.Properties.Release, template.Properties.PerformanceClass)
+		if template.Properties.ObjectUUID == currentUUID.(string) {
+			props := template.Properties
+			currentTemplate = &props
+		}
+	}
+	targetUUID := chosenTemplate.Properties.ObjectUUID
+	if err := d.SetNew("service_template_uuid", targetUUID); err != nil {
+		return err
+	}
+	if currentTemplate == nil {
+		// The update paths of the current template are unknown, leave the decision to the API.
+		log.Printf("[WARN] service template %v of PaaS service %s is not available anymore, the change to template %s cannot be validated", currentUUID, d.Id(), targetUUID)
+		return d.SetNewComputed("template_change")
+	}
+
+	change := paasu.ClassifyTemplateChange(currentTemplate.ObjectUUID, targetUUID,
+		currentTemplate.PatchUpdates, currentTemplate.VersionUpgrades, currentTemplate.PerformanceClassUpdates)
+	if err := d.SetNew("template_change", change); err != nil {
+		return err
+	}
+	if change != paasu.TemplateChangeReplace {
+		return nil
+	}
+	if !d.Get("allow_upgrade_by_replace").(bool) {
+		targets := paasu.InPlaceTemplateChanges(descriptions,
+			currentTemplate.PatchUpdates, currentTemplate.VersionUpgrades, currentTemplate.PerformanceClassUpdates)
+		if len(targets) == 0 {
+			targets = append(targets, "none")
+		}
+		return fmt.Errorf(
+			"release %s with performance class %s cannot be changed in-place to release %s with performance class %s. Set allow_upgrade_by_replace to replace the service (all data is lost). In-place changes are possible to: %s",
+			currentTemplate.Release, currentTemplate.PerformanceClass,
+			chosenTemplate.Properties.Release, chosenTemplate.Properties.PerformanceClass,
+			strings.Join(targets, "; "),
+		)
+	}
+	for _, key := range []string{"release", "performance_class"} {
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -65,15 +65,19 @@ func Provider() *schema.Provider {
 			"gridscale_loadbalancer":             dataSourceGridscaleLoadBalancer(),
 			"gridscale_snapshot":                 dataSourceGridscaleStorageSnapshot(),
 			"gridscale_backup_list":              dataSourceGridscaleStorageBackupList(),
+			"gridscale_backup_locations":         dataSourceGridscaleBackupLocations(),
 			"gridscale_snapshotschedule":         dataSourceGridscaleStorageSnapshotSchedule(),
 			"gridscale_backupschedule":           dataSourceGridscaleStorageBackupSchedule(),
 			"gridscale_paas":                     dataSourceGridscalePaaS(),
 			"gridscale_paas_securityzone":        dataSourceGridscalePaaSSecurityZone(),
+			"gridscale_paas_templates":           dataSourceGridscalePaaSTemplates(),
 			"gridscale_object_storage_accesskey": dataSourceGridscaleObjectStorage(),
 			"gridscale_isoimage":                 dataSourceGridscaleISOImage(),
 			"gridscale_firewall":                 dataSourceGridscaleFirewall(),
+			"gridscale_firewall_address_group":   dataSourceGridscaleFirewallAddressGroup(),
 			"gridscale_marketplace_application":  dataSourceGridscaleMarketplaceApplication(),
 			"gridscale_ssl_certificate":          dataSourceGridscaleSSLCert(),
+			"gridscale_rocket_storages_usage":    dataSourceGridscaleRocketStoragesUsage(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"gridscale_server":                         resourceGridscaleServer(),
@@ -87,22 +91,35 @@ func Provider() *schema.Provider {
 			"gridscale_loadbalancer":                   resourceGridscaleLoadBalancer(),
 			"gridscale_snapshot":                       resourceGridscaleStorageSnapshot(),
 			"gridscale_snapshotschedule":               resourceGridscaleStorageSnapshotSchedule(),
+			"gridscale_snapshot_rotation":              resourceGridscaleSnapshotRotation(),
 			"gridscale_backupschedule":                 resourceGridscaleStorageBackupSchedule(),
+			"gridscale_storage_backup_restore":         resourceGridscaleStorageBackupRestore(),
+			"gridscale_storage_backup_prune":           resourceGridscaleStorageBackupPrune(),
 			"gridscale_paas":                           resourceGridscalePaaS(),
 			"gridscale_redis_store":                    resourceGridscaleRedisStore(),
 			"gridscale_redis_cache":                    resourceGridscaleRedisCache(),
 			"gridscale_k8s":                            resourceGridscaleK8s(),
 			"gridscale_paas_securityzone":              resourceGridscalePaaSSecurityZone(),
 			"gridscale_postgresql":                     resourceGridscalePostgreSQL(),
+			"gridscale_postgresql_role":                resourceGridscalePostgreSQLRole(),
+			"gridscale_postgresql_database":            resourceGridscalePostgreSQLDatabase(),
+			"gridscale_postgresql_schema":              resourceGridscalePostgreSQLSchema(),
+			"gridscale_postgresql_grant":               resourceGridscalePostgreSQLGrant(),
 			"gridscale_sqlserver":                      resourceGridscaleMSSQLServer(),
 			"gridscale_mysql":                          resourceGridscaleMySQL(),
+			"gridscale_mysql_user":                     resourceGridscaleMySQLUser(),
+			"gridscale_mysql_database":                 resourceGridscaleMySQLDatabase(),
+			"gridscale_mysql_grant":                    resourceGridscaleMySQLGrant(),
 			"gridscale_mariadb":                        resourceGridscaleMariaDB(),
 			"gridscale_memcached":                      resourceGridscaleMemcached(),
 			"gridscale_filesystem":                     resourceGridscaleFilesystem(),
+			"gridscale_filesystem_access":              resourceGridscaleFilesystemAccess(),
 			"gridscale_object_storage_accesskey":       resourceGridscaleObjectStorage(),
 			"gridscale_template":                       resourceGridscaleTemplate(),
+			"gridscale_template_from_server":           resourceGridscaleTemplateFromServer(),
 			"gridscale_isoimage":                       resourceGridscaleISOImage(),
 			"gridscale_firewall":                       resourceGridscaleFirewall(),
+			"gridscale_firewall_rule":                  resourceGridscaleFirewallRule(),
 			"gridscale_marketplace_application":        resourceGridscaleMarketplaceApplication(),
 			"gridscale_marketplace_application_import": resourceGridscaleImportedMarketplaceApplication(),
 			"gridscale_ssl_certificate":                resourceGridscaleSSLCert(),
@@ -132,12 +132,15 @@ func (c *ServerRelationManger) LinkNetworks(ctx context.Context) error {
 			var customFwRulesPtr *gsclient.FirewallRules
 			network := value.(map[string]interface{})
 			//Read custom firewall rules from `network` property (field)
-			customFwRules := readCustomFirewallRules(network)
+			customFwRules, err := readCustomFirewallRules(network)
+			if err != nil {
+				return fmt.Errorf("invalid firewall rules of network (%s): %v", network["object_uuid"], err)
+			}
 			// if customFwRules is not empty, customFwRulesPtr is not nil (fw is active)
 			if !reflect.DeepEqual(customFwRules, gsclient.FirewallRules{}) {
 				customFwRulesPtr = &customFwRules
 			}
-			err := client.LinkNetwork(
+			err = client.LinkNetwork(
 				ctx,
 				d.Id(),
 				network["object_uuid"].(string),
@@ -183,51 +186,52 @@ func (c *ServerRelationManger) LinkNetworks(ctx context.Context) error {
 
 // readCustomFirewallRules reads custom firewall rules from a specific network
 // returns `gsclient.FirewallRules` type variable
-func readCustomFirewallRules(netData map[string]interface{}) gsclient.FirewallRules {
+func readCustomFirewallRules(netData map[string]interface{}) (gsclient.FirewallRules, error) {
 	//Init firewall rule variable
 	var fwRules gsclient.FirewallRules
 
+	//Read the mode of default inbound rules, and the custom default inbound rules (if there are any)
+	mode, _ := netData["default_inbound_rules"].(string)
+	var customDefaultRulesV4In, customDefaultRulesV6In []gsclient.FirewallRuleProperties
+	var err error
+	if rulesIntf, ok := netData["custom_default_rules_v4_in"]; ok {
+		if customDefaultRulesV4In, err = fwu.ConvInterfaceSliceToFirewallRules(rulesIntf.([]interface{})); err != nil {
+			return fwRules, fmt.Errorf("custom_default_rules_v4_in: %v", err)
+		}
+	}
+	if rulesIntf, ok := netData["custom_default_rules_v6_in"]; ok {
+		if customDefaultRulesV6In, err = fwu.ConvInterfaceSliceToFirewallRules(rulesIntf.([]interface{})); err != nil {
+			return fwRules, fmt.Errorf("custom_default_rules_v6_in: %v", err)
+		}
+	}
+
 	//Loop through all firewall rule types
 	//there are 4 types: "rules_v4_in", "rules_v4_out", "rules_v6_in", "rules_v6_out".
 	for _, ruleType := range firewallRuleTypes {
 		//Init array of firewall rules
 		var rules []gsclient.FirewallRuleProperties
 		//Check if the firewall rule type is declared in the current network
+		//Services and lists of CIDRs are expanded to concrete rules
 		if rulesInTypeAttr, ok := netData[ruleType]; ok {
-			//Loop through all rules in the current firewall type
-			for _, rulesInType := range rulesInTypeAttr.([]interface{}) {
-				ruleProps := rulesInType.(map[string]interface{})
-				ruleProperties := gsclient.FirewallRuleProperties{
-					DstPort: ruleProps["dst_port"].(string),
-					SrcPort: ruleProps["src_port"].(string),
-					SrcCidr: ruleProps["src_cidr"].(string),
-					Action:  ruleProps["action"].(string),
-					Comment: ruleProps["comment"].(string),
-					DstCidr: ruleProps["dst_cidr"].(string),
-					Order:   ruleProps["order"].(int),
-				}
-				if ruleProps["protocol"].(string) == "tcp" {
-					ruleProperties.Protocol = gsclient.TCPTransport
-				} else if ruleProps["protocol"].(string) == "udp" {
-					ruleProperties.Protocol = gsclient.UDPTransport
-				}
-				//Add rule to the array of rules
-				rules = append(rules, ruleProperties)
+			if rules, err = fwu.ConvInterfaceSliceToFirewallRules(rulesInTypeAttr.([]interface{})); err != nil {
+				return fwRules, fmt.Errorf("%s: %v", ruleType, err)
 			}
 		}
 
 		//Based on rule type to place the rules in the right property of fwRules variable
 		if ruleType == "rules_v4_in" {
-			fwRules.RulesV4In = fwu.AddDefaultFirewallInboundRules(rules, false) // add default rules
+			// add default rules
+			fwRules.RulesV4In = fwu.AddDefaultFirewallInboundRules(rules, fwu.DefaultFirewallInboundRules(mode, customDefaultRulesV4In, false))
 		} else if ruleType == "rules_v4_out" {
 			fwRules.RulesV4Out = rules
 		} else if ruleType == "rules_v6_in" {
-			fwRules.RulesV6In = fwu.AddDefaultFirewallInboundRules(rules, true) // add default rules
+			// add default rules
+			fwRules.RulesV6In = fwu.AddDefaultFirewallInboundRules(rules, fwu.DefaultFirewallInboundRules(mode, customDefaultRulesV6In, true))
 		} else if ruleType == "rules_v6_out" {
 			fwRules.RulesV6Out = rules
 		}
 	}
-	return fwRules
+	return fwRules, nil
 }
 
 // IsShutdownRequired checks if server is needed to be shutdown when updating
@@ -364,8 +368,11 @@ func (c *ServerRelationManger) UpdateNetRelsProperties(ctx context.Context) erro
 	for idx, networkIntf := range networkListIntf {
 		network := networkIntf.(map[string]interface{})
 		//Read custom firewall rules from `network` property (field)
-		customFwRules := readCustomFirewallRules(network)
-		err := client.UpdateServerNetwork(
+		customFwRules, err := readCustomFirewallRules(network)
+		if err != nil {
+			return fmt.Errorf("invalid firewall rules of network (%s): %v", network["object_uuid"], err)
+		}
+		err = client.UpdateServerNetwork(
 			ctx,
 			d.Id(),
 			network["object_uuid"].(string),
@@ -15,7 +15,7 @@ import (
 )
 
 func resourceGridscaleStorageBackupSchedule() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscaleBackupScheduleCreate,
 		Read:   resourceGridscaleBackupScheduleRead,
 		Delete: resourceGridscaleBackupScheduleDelete,
@@ -31,8 +31,9 @@ func resourceGridscaleStorageBackupSchedule() *schema.Resource {
 			},
 			"next_runtime": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The date and time that the storage backup schedule will be run. Format: \"2006-01-02 15:04:05\"",
+				Optional:    true,
+				Computed:    true,
+				Description: "The date and time that the storage backup schedule will be run. Format: \"2006-01-02 15:04:05\". Required if cron is not set.",
 			},
 			"next_runtime_computed": {
 				Type:        schema.TypeString,
@@ -47,9 +48,10 @@ func resourceGridscaleStorageBackupSchedule() *schema.Resource {
 			},
 			"run_interval": {
 				Type:         schema.TypeInt,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(60),
-				Description:  "The interval at which the schedule will run (in minutes)",
+				Description:  "The interval at which the schedule will run (in minutes). Required if cron is not set.",
 			},
 			"storage_uuid": {
 				Type:        schema.TypeString,
@@ -116,7 +118,12 @@ func resourceGridscaleStorageBackupSchedule() *schema.Resource {
 			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return customizeScheduleCronDiff(d, true)
+		},
 	}
+	addScheduleCronSchema(resource.Schema)
+	return resource
 }
 
 func resourceGridscaleBackupScheduleRead(d *schema.ResourceData, meta interface{}) error {
@@ -152,6 +159,13 @@ func resourceGridscaleBackupScheduleRead(d *schema.ResourceData, meta interface{
 	if err = d.Set("next_runtime_computed", props.NextRuntime.Format(timeLayout)); err != nil {
 		return fmt.Errorf("%s error setting next_runtime_computed: %v", errorPrefix, err)
 	}
+	// If the schedule is defined by a cron expression, next_runtime is computed as well.
+	// It is re-aligned with the cron expression by CustomizeDiff if it drifted.
+	if d.Get("cron").(string) != "" {
+		if err = d.Set("next_runtime", props.NextRuntime.Format(timeLayout)); err != nil {
+			return fmt.Errorf("%s error setting next_runtime: %v", errorPrefix, err)
+		}
+	}
 	if err = d.Set("keep_backups", props.KeepBackups); err != nil {
 		return fmt.Errorf("%s error setting keep_backups: %v", errorPrefix, err)
 	}
@@ -3,6 +3,7 @@ package gridscale
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -146,3 +147,55 @@ resource "gridscale_backupschedule" "foo" {
 }
 `)
 }
+
+func TestAccResourceGridscaleBackupSchedule_Cron(t *testing.T) {
+	var object gsclient.StorageBackupSchedule
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataSourceGridscaleBackupScheduleDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourceGridscaleBackupScheduleConfig_cron(name, "30 2 * * *"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceGridscaleBackupScheduleExists("gridscale_backupschedule.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_backupschedule.foo", "run_interval", "1440"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_backupschedule.foo", "next_runtime"),
+				),
+			},
+			{
+				Config: testAccCheckDataSourceGridscaleBackupScheduleConfig_cron(name, "0 */6 * * *"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceGridscaleBackupScheduleExists("gridscale_backupschedule.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_backupschedule.foo", "run_interval", "360"),
+				),
+			},
+			{
+				Config:      testAccCheckDataSourceGridscaleBackupScheduleConfig_cron(name, "0 3 * * MON-FRI"),
+				ExpectError: regexp.MustCompile("not evenly spaced"),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceGridscaleBackupScheduleConfig_cron(name, cron string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "storage"
+  capacity = 1
+}
+resource "gridscale_backupschedule" "foo" {
+  name = "%s"
+  storage_uuid = gridscale_storage.foo.id
+  keep_backups = 1
+  cron = "%s"
+  timezone = "Europe/Berlin"
+  active = true
+}
+`, name, cron)
+}
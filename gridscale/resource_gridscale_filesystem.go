@@ -69,7 +69,7 @@ func resourceGridscaleFilesystem() *schema.Resource {
 			"release": {
 				Type: schema.TypeString,
 				Description: `The Filesystem service release of this instance.\n
-				For convenience, please use gscloud https://github.com/gridscale/gscloud to get the list of available Filesystem service releases.`,
+				Use the gridscale_paas_templates data source (or gscloud https://github.com/gridscale/gscloud) to get the list of available Filesystem service releases.`,
 				Required:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
@@ -127,8 +127,9 @@ func resourceGridscaleFilesystem() *schema.Resource {
 			},
 			"allowed_ip_ranges": {
 				Type:        schema.TypeSet,
-				Description: "Allowed CIDR block or IP address in CIDR notation.",
+				Description: "Allowed CIDR block or IP address in CIDR notation. Single ranges can also be added by gridscale_filesystem_access resources.",
 				Optional:    true,
+				Computed:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"anon_uid": {
@@ -385,6 +386,18 @@ func resourceGridscaleFilesystemUpdate(d *schema.ResourceData, meta interface{})
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
+	//Lock the filesystem, as its allowed IP ranges could be modified by gridscale_filesystem_access resources at the same time
+	globalObjectLockList.lock(d.Id())
+	defer globalObjectLockList.unlock(d.Id())
+	// Keep the current allowed IP ranges, if they are not changed. They might have been
+	// modified by gridscale_filesystem_access resources since the state was read.
+	if !d.HasChange("allowed_ip_ranges") {
+		paas, err := client.GetPaaSService(ctx, d.Id())
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		params["allowed_ip_ranges"] = getFilesystemAllowedIPRanges(paas.Properties)
+	}
 	err := client.UpdatePaaSService(ctx, d.Id(), requestBody)
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
@@ -0,0 +1,228 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
+)
+
+func resourceGridscaleFilesystemAccess() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourceGridscaleFilesystemAccessRead,
+		Create: resourceGridscaleFilesystemAccessCreate,
+		Delete: resourceGridscaleFilesystemAccessDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGridscaleFilesystemAccessImport,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Only new accesses are checked, existing ones are not affected by later changes of the network
+			if d.Id() != "" || !d.NewValueKnown("filesystem_uuid") || !d.NewValueKnown("ip_range") {
+				return nil
+			}
+			client := meta.(*gsclient.Client)
+			return validateFilesystemAccessIPRange(ctx, client, d.Get("filesystem_uuid").(string), d.Get("ip_range").(string))
+		},
+		Schema: map[string]*schema.Schema{
+			"filesystem_uuid": {
+				Type:         schema.TypeString,
+				Description:  "The UUID of the filesystem the IP range is allowed to access.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"ip_range": {
+				Type:         schema.TypeString,
+				Description:  "Allowed CIDR block, e.g. 192.168.121.32/28. A single IP address is written with the prefix length /32 (IPv4) or /128 (IPv6). It must be inside the DHCP range of the network of the filesystem.",
+				Required:     true,
+				ForceNew:     true,
+				// The ranges of the filesystem are compared literally, so only the canonical network notation is accepted
+				ValidateFunc: validation.IsCIDRNetwork(0, 128),
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleFilesystemAccessImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// ID format: <filesystem_uuid>/<ip_range>, the ip_range contains a "/" itself
+	idParts := strings.SplitN(d.Id(), "/", 2)
+	if len(idParts) != 2 {
+		return nil, fmt.Errorf("invalid filesystem access ID (%s), expected format: <filesystem_uuid>/<ip_range>", d.Id())
+	}
+	if err := d.Set("filesystem_uuid", idParts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("ip_range", idParts[1]); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGridscaleFilesystemAccessRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("read filesystem access (%s) resource -", d.Id())
+	paas, err := client.GetPaaSService(context.Background(), d.Get("filesystem_uuid").(string))
+	if err != nil {
+		if requestError, ok := err.(gsclient.RequestError); ok {
+			if requestError.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	// If the IP range is not found, it was removed outside of terraform
+	if findFilesystemIPRange(getFilesystemAllowedIPRanges(paas.Properties), d.Get("ip_range").(string)) == -1 {
+		log.Printf("[WARN] filesystem access (%s) not found, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	return nil
+}
+
+func resourceGridscaleFilesystemAccessCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	filesystemUUID := d.Get("filesystem_uuid").(string)
+	ipRange := d.Get("ip_range").(string)
+	errorPrefix := fmt.Sprintf("create filesystem access (%s) to filesystem (%s) resource -", ipRange, filesystemUUID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	// The filesystem might not have been known at plan time
+	if err := validateFilesystemAccessIPRange(ctx, client, filesystemUUID, ipRange); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	err := modifyFilesystemAllowedIPRangesSynchronously(ctx, client, filesystemUUID, func(ipRanges []string) ([]string, error) {
+		if findFilesystemIPRange(ipRanges, ipRange) != -1 {
+			return nil, fmt.Errorf("conflict: %s is already allowed to access the filesystem", ipRange)
+		}
+		return append(ipRanges, ipRange), nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	id := fmt.Sprintf("%s/%s", filesystemUUID, ipRange)
+	d.SetId(id)
+
+	log.Printf("The id for the new filesystem access has been set to %v", id)
+
+	return resourceGridscaleFilesystemAccessRead(d, meta)
+}
+
+func resourceGridscaleFilesystemAccessDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("delete filesystem access (%s) resource -", d.Id())
+	ipRange := d.Get("ip_range").(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	err := errHandler.SuppressHTTPErrorCodes(
+		modifyFilesystemAllowedIPRangesSynchronously(ctx, client, d.Get("filesystem_uuid").(string), func(ipRanges []string) ([]string, error) {
+			if idx := findFilesystemIPRange(ipRanges, ipRange); idx != -1 {
+				ipRanges = append(ipRanges[:idx], ipRanges[idx+1:]...)
+			}
+			return ipRanges, nil
+		}),
+		http.StatusNotFound,
+	)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+// modifyFilesystemAllowedIPRangesSynchronously modifies the allowed IP ranges of a filesystem
+// (read-modify-write). The filesystem is locked during the modification, so that multiple
+// resources can modify the allowed IP ranges of the same filesystem concurrently.
+func modifyFilesystemAllowedIPRangesSynchronously(
+	ctx context.Context,
+	client *gsclient.Client,
+	filesystemUUID string,
+	modify func(ipRanges []string) ([]string, error)) error {
+	globalObjectLockList.lock(filesystemUUID)
+	defer globalObjectLockList.unlock(filesystemUUID)
+
+	paas, err := client.GetPaaSService(ctx, filesystemUUID)
+	if err != nil {
+		return err
+	}
+	ipRanges, err := modify(getFilesystemAllowedIPRanges(paas.Properties))
+	if err != nil {
+		return err
+	}
+	// Keep the other parameters (e.g. root_squash) of the filesystem
+	params := make(map[string]interface{})
+	for k, v := range paas.Properties.Parameters {
+		params[k] = v
+	}
+	params["allowed_ip_ranges"] = ipRanges
+	return client.UpdatePaaSService(ctx, filesystemUUID, gsclient.PaaSServiceUpdateRequest{
+		Parameters: params,
+	})
+}
+
+// getFilesystemAllowedIPRanges returns the allowed IP ranges of a filesystem.
+func getFilesystemAllowedIPRanges(props gsclient.PaaSServiceProperties) []string {
+	ipRanges, _ := props.Parameters["allowed_ip_ranges"].([]interface{})
+	return convSOStrings(ipRanges)
+}
+
+// findFilesystemIPRange returns the index of an IP range in a list of IP ranges,
+// returns -1 if the list does not contain the IP range
+func findFilesystemIPRange(ipRanges []string, ipRange string) int {
+	for i, r := range ipRanges {
+		if r == ipRange {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateFilesystemAccessIPRange checks that an IP range is inside the DHCP range of the network
+// which a filesystem is attached to. Filesystems without a network with an active DHCP are not checked.
+func validateFilesystemAccessIPRange(ctx context.Context, client *gsclient.Client, filesystemUUID, ipRange string) error {
+	paas, err := client.GetPaaSService(ctx, filesystemUUID)
+	if err != nil {
+		return fmt.Errorf("error getting filesystem (%s): %v", filesystemUUID, err)
+	}
+	networkUUID := paas.Properties.NetworkUUID
+	if networkUUID == "" {
+		return nil
+	}
+	network, err := client.GetNetwork(ctx, networkUUID)
+	if err != nil {
+		return fmt.Errorf("error getting network (%s) of filesystem (%s): %v", networkUUID, filesystemUUID, err)
+	}
+	dhcpRange := network.Properties.DHCPRange
+	if !network.Properties.DHCPActive || dhcpRange == "" {
+		log.Printf("[DEBUG] network (%s) of filesystem (%s) has no DHCP range, %s is not checked", networkUUID, filesystemUUID, ipRange)
+		return nil
+	}
+	_, dhcpNet, err := net.ParseCIDR(dhcpRange)
+	if err != nil {
+		return fmt.Errorf("invalid DHCP range %s of network (%s): %v", dhcpRange, networkUUID, err)
+	}
+	_, ipNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return err
+	}
+	dhcpPrefixLength, _ := dhcpNet.Mask.Size()
+	ipPrefixLength, _ := ipNet.Mask.Size()
+	if !dhcpNet.Contains(ipNet.IP) || ipPrefixLength < dhcpPrefixLength {
+		return fmt.Errorf("%s is not inside the DHCP range %s of the network (%s) of filesystem (%s)", ipRange, dhcpRange, networkUUID, filesystemUUID)
+	}
+	return nil
+}
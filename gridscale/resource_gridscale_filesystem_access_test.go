@@ -0,0 +1,107 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func TestAccResourceGridscaleFilesystemAccess_Basic(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("filesystem-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleFilesystemAccessConfig_basic(name, "192.168.121.32/28"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_filesystem.test", &object),
+					testAccCheckResourceGridscaleFilesystemAccessExists("gridscale_filesystem_access.app"),
+					testAccCheckResourceGridscaleFilesystemAccessExists("gridscale_filesystem_access.db"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleFilesystemAccessConfig_basic(name, "192.168.121.64/28"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleFilesystemAccessExists("gridscale_filesystem_access.app"),
+					resource.TestCheckResourceAttr(
+						"gridscale_filesystem_access.app", "ip_range", "192.168.121.64/28"),
+				),
+			},
+			{
+				Config:      testAccCheckResourceGridscaleFilesystemAccessConfig_basic(name, "10.0.0.0/28"),
+				ExpectError: regexp.MustCompile("is not inside the DHCP range"),
+			},
+			{
+				ResourceName:      "gridscale_filesystem_access.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleFilesystemAccessExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No object ID is set")
+		}
+
+		client := testAccProvider.Meta().(*gsclient.Client)
+
+		foundObject, err := client.GetPaaSService(context.Background(), rs.Primary.Attributes["filesystem_uuid"])
+
+		if err != nil {
+			return err
+		}
+
+		if findFilesystemIPRange(getFilesystemAllowedIPRanges(foundObject.Properties), rs.Primary.Attributes["ip_range"]) == -1 {
+			return fmt.Errorf("Object not found")
+		}
+		return nil
+	}
+}
+
+func testAccCheckResourceGridscaleFilesystemAccessConfig_basic(name, appIPRange string) string {
+	return fmt.Sprintf(`
+resource "gridscale_network" "test" {
+	name = "%s"
+	dhcp_active = true
+	dhcp_range = "192.168.121.0/24"
+}
+
+resource "gridscale_filesystem" "test" {
+	name = "%s"
+	release = "1"
+	performance_class = "standard"
+	network_uuid = gridscale_network.test.id
+	root_squash = true
+}
+
+resource "gridscale_filesystem_access" "app" {
+	filesystem_uuid = gridscale_filesystem.test.id
+	ip_range = "%s"
+}
+
+resource "gridscale_filesystem_access" "db" {
+	filesystem_uuid = gridscale_filesystem.test.id
+	ip_range = "192.168.121.10/32"
+}
+`, name, name, appIPRange)
+}
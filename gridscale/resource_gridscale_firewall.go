@@ -25,6 +25,47 @@ func resourceGridscaleFirewall() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			mode := d.Get("default_inbound_rules").(string)
+			customRulesV4In := d.Get("custom_default_rules_v4_in").([]interface{})
+			customRulesV6In := d.Get("custom_default_rules_v6_in").([]interface{})
+			if err := validateDefaultInboundRulesConfig(mode, customRulesV4In, customRulesV6In); err != nil {
+				return err
+			}
+			// Show the default inbound rules which will be enforced in the plan.
+			for ruleType, forIPv6 := range map[string]bool{"rules_v4_in": false, "rules_v6_in": true} {
+				defaultRuleType := fmt.Sprintf("default_%s", ruleType)
+				customDefaultRuleType := fmt.Sprintf("custom_default_%s", ruleType)
+				if !d.NewValueKnown(ruleType) || !d.NewValueKnown(customDefaultRuleType) {
+					if err := d.SetNewComputed(defaultRuleType); err != nil {
+						return err
+					}
+					continue
+				}
+				rules, err := fwu.ConvInterfaceSliceToFirewallRules(d.Get(ruleType).([]interface{}))
+				if err != nil {
+					return fmt.Errorf("%s: %v", ruleType, err)
+				}
+				defaultRules, err := expandDefaultFirewallInboundRules(mode, d.Get(customDefaultRuleType).([]interface{}), forIPv6)
+				if err != nil {
+					return fmt.Errorf("%s: %v", customDefaultRuleType, err)
+				}
+				rulesWithDefault := fwu.AddDefaultFirewallInboundRules(rules, defaultRules)
+				if err := d.SetNew(defaultRuleType, convFirewallRuleSliceToInterfaceSlice(rulesWithDefault[len(rules):])); err != nil {
+					return err
+				}
+			}
+			// Validate the outbound rules
+			for _, ruleType := range []string{"rules_v4_out", "rules_v6_out"} {
+				if !d.NewValueKnown(ruleType) {
+					continue
+				}
+				if _, err := fwu.ConvInterfaceSliceToFirewallRules(d.Get(ruleType).([]interface{})); err != nil {
+					return fmt.Errorf("%s: %v", ruleType, err)
+				}
+			}
+			return nil
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -38,12 +79,52 @@ func resourceGridscaleFirewall() *schema.Resource {
 				Optional: true,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: getFirewallRuleCommonSchema(),
+					Schema: getFirewallRuleSchemaWithReferences(),
 				},
 			},
 			"rules_v4_out": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Elem: &schema.Resource{
+					Schema: getFirewallRuleSchemaWithReferences(),
+				},
+			},
+			"default_inbound_rules": {
+				Type: schema.TypeString,
+				Description: `Defines which default rules are added after the custom inbound rules (rules_v4_in, rules_v6_in).
+"gridscale" (default) adds the gridscale default rules (DHCP, high ports, drop all other traffic),
+"none" does not add any rules, "custom" adds the rules of custom_default_rules_v4_in and custom_default_rules_v6_in.`,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(fwu.DefaultInboundRulesModes, false),
+			},
+			"custom_default_rules_v4_in": {
+				Type:        schema.TypeList,
+				Description: `Default inbound rules (ipv4) added after the custom inbound rules, if default_inbound_rules is "custom".`,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: getFirewallDefaultRuleSchema(),
+				},
+			},
+			"custom_default_rules_v6_in": {
+				Type:        schema.TypeList,
+				Description: `Default inbound rules (ipv6) added after the custom inbound rules, if default_inbound_rules is "custom".`,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: getFirewallDefaultRuleSchema(),
+				},
+			},
+			"default_rules_v4_in": {
+				Type:        schema.TypeList,
+				Description: "Default inbound rules (ipv4) which are enforced after the custom inbound rules.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: getFirewallRuleCommonSchema(),
+				},
+			},
+			"default_rules_v6_in": {
+				Type:        schema.TypeList,
+				Description: "Default inbound rules (ipv6) which are enforced after the custom inbound rules.",
+				Computed:    true,
 				Elem: &schema.Resource{
 					Schema: getFirewallRuleCommonSchema(),
 				},
@@ -53,14 +134,14 @@ func resourceGridscaleFirewall() *schema.Resource {
 				Optional: true,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: getFirewallRuleCommonSchema(),
+					Schema: getFirewallRuleSchemaWithReferences(),
 				},
 			},
 			"rules_v6_out": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: getFirewallRuleCommonSchema(),
+					Schema: getFirewallRuleSchemaWithReferences(),
 				},
 			},
 			"status": {
@@ -188,30 +269,30 @@ func resourceGridscaleFirewallRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("%s error setting network: %v", errorPrefix, err)
 	}
 
-	//Get rules_v4_in
-	rulesV4InWODefaultRules := fwu.RemoveDefaultFirewallInboundRules(props.Rules.RulesV4In)
-	rulesV4In := convFirewallRuleSliceToInterfaceSlice(rulesV4InWODefaultRules)
-	if err = d.Set("rules_v4_in", rulesV4In); err != nil {
-		return fmt.Errorf("%s error setting rules_v4_in: %v", errorPrefix, err)
-	}
-
-	//Get rules_v4_out
-	rulesV4Out := convFirewallRuleSliceToInterfaceSlice(props.Rules.RulesV4Out)
-	if err = d.Set("rules_v4_out", rulesV4Out); err != nil {
-		return fmt.Errorf("%s error setting rules_v4_out: %v", errorPrefix, err)
-	}
-
-	//Get rules_v6_in
-	rulesV6InWODefaultRules := fwu.RemoveDefaultFirewallInboundRules(props.Rules.RulesV6In)
-	rulesV6In := convFirewallRuleSliceToInterfaceSlice(rulesV6InWODefaultRules)
-	if err = d.Set("rules_v6_in", rulesV6In); err != nil {
-		return fmt.Errorf("%s error setting rules_v6_in: %v", errorPrefix, err)
-	}
-
-	//Get rules_v6_out
-	rulesV6Out := convFirewallRuleSliceToInterfaceSlice(props.Rules.RulesV6Out)
-	if err = d.Set("rules_v6_out", rulesV6Out); err != nil {
-		return fmt.Errorf("%s error setting rules_v6_out: %v", errorPrefix, err)
+	mode := d.Get("default_inbound_rules").(string)
+	for ruleType, rules := range map[string][]gsclient.FirewallRuleProperties{
+		"rules_v4_in":  props.Rules.RulesV4In,
+		"rules_v4_out": props.Rules.RulesV4Out,
+		"rules_v6_in":  props.Rules.RulesV6In,
+		"rules_v6_out": props.Rules.RulesV6Out,
+	} {
+		//Split default inbound rules from the custom inbound rules
+		if ruleType == "rules_v4_in" || ruleType == "rules_v6_in" {
+			defaultRuleType := fmt.Sprintf("default_%s", ruleType)
+			expectedDefaultRules, err := expandDefaultFirewallInboundRules(mode, d.Get(fmt.Sprintf("custom_%s", defaultRuleType)).([]interface{}), ruleType == "rules_v6_in")
+			if err != nil {
+				return fmt.Errorf("%s error: %v", errorPrefix, err)
+			}
+			var defaultRules []gsclient.FirewallRuleProperties
+			rules, defaultRules = fwu.SplitDefaultFirewallInboundRules(rules, expectedDefaultRules)
+			if err = d.Set(defaultRuleType, convFirewallRuleSliceToInterfaceSlice(defaultRules)); err != nil {
+				return fmt.Errorf("%s error setting %s: %v", errorPrefix, defaultRuleType, err)
+			}
+		}
+		//Keep declared services and lists of CIDRs if they still match the rules
+		if err = d.Set(ruleType, flattenFirewallRulesWithConfig(rules, d.Get(ruleType).([]interface{}))); err != nil {
+			return fmt.Errorf("%s error setting %s: %v", errorPrefix, ruleType, err)
+		}
 	}
 
 	if err = d.Set("labels", props.Labels); err != nil {
@@ -223,33 +304,19 @@ func resourceGridscaleFirewallRead(d *schema.ResourceData, meta interface{}) err
 
 func resourceGridscaleFirewallCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*gsclient.Client)
-	var rulesV4In, rulesV4Out, rulesV6In, rulesV6Out []gsclient.FirewallRuleProperties
 	//Get firewall rules from schema
-	if attr, ok := d.GetOk("rules_v4_in"); ok {
-		rulesV4In = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
-	}
-	if attr, ok := d.GetOk("rules_v4_out"); ok {
-		rulesV4Out = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
-	}
-	if attr, ok := d.GetOk("rules_v6_in"); ok {
-		rulesV6In = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
-	}
-	if attr, ok := d.GetOk("rules_v6_out"); ok {
-		rulesV6Out = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
+	rules, err := expandFirewallRules(d)
+	if err != nil {
+		return err
 	}
 	//at least one rules in firewall create request
-	if len(rulesV4In) == 0 && len(rulesV4Out) == 0 && len(rulesV6In) == 0 && len(rulesV6Out) == 0 {
+	if len(rules.RulesV4In) == 0 && len(rules.RulesV4Out) == 0 && len(rules.RulesV6In) == 0 && len(rules.RulesV6Out) == 0 {
 		return errors.New("at least 1 firewall rule in create request")
 	}
 	requestBody := gsclient.FirewallCreateRequest{
 		Name:   d.Get("name").(string),
 		Labels: convSOStrings(d.Get("labels").(*schema.Set).List()),
-		Rules: gsclient.FirewallRules{
-			RulesV6In:  fwu.AddDefaultFirewallInboundRules(rulesV6In, true),
-			RulesV6Out: rulesV6Out,
-			RulesV4In:  fwu.AddDefaultFirewallInboundRules(rulesV4In, false),
-			RulesV4Out: rulesV4Out,
-		},
+		Rules:  rules,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
@@ -270,21 +337,12 @@ func resourceGridscaleFirewallUpdate(d *schema.ResourceData, meta interface{}) e
 	client := meta.(*gsclient.Client)
 	errorPrefix := fmt.Sprintf("update firewall (%s) resource -", d.Id())
 
-	var rulesV4In, rulesV4Out, rulesV6In, rulesV6Out []gsclient.FirewallRuleProperties
-	if attr, ok := d.GetOk("rules_v4_in"); ok {
-		rulesV4In = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
-	}
-	if attr, ok := d.GetOk("rules_v4_out"); ok {
-		rulesV4Out = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
-	}
-	if attr, ok := d.GetOk("rules_v6_in"); ok {
-		rulesV6In = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
-	}
-	if attr, ok := d.GetOk("rules_v6_out"); ok {
-		rulesV6Out = convInterfaceSliceToFirewallRulesSlice(attr.([]interface{}))
+	rules, err := expandFirewallRules(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
 	//at least one rules in firewall create request
-	if len(rulesV4In) == 0 && len(rulesV4Out) == 0 && len(rulesV6In) == 0 && len(rulesV6Out) == 0 {
+	if len(rules.RulesV4In) == 0 && len(rules.RulesV4Out) == 0 && len(rules.RulesV6In) == 0 && len(rules.RulesV6Out) == 0 {
 		return fmt.Errorf("%s error: At least 1 firewall rule in update request", errorPrefix)
 	}
 	labels := convSOStrings(d.Get("labels").(*schema.Set).List())
@@ -292,16 +350,14 @@ func resourceGridscaleFirewallUpdate(d *schema.ResourceData, meta interface{}) e
 		Name:   d.Get("name").(string),
 		Labels: &labels,
 	}
-	requestBody.Rules = &gsclient.FirewallRules{
-		RulesV6In:  fwu.AddDefaultFirewallInboundRules(rulesV6In, true),
-		RulesV6Out: rulesV6Out,
-		RulesV4In:  fwu.AddDefaultFirewallInboundRules(rulesV4In, false),
-		RulesV4Out: rulesV4Out,
-	}
+	requestBody.Rules = &rules
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
-	err := client.UpdateFirewall(ctx, d.Id(), requestBody)
+	//Lock the firewall, as its rules could be modified by gridscale_firewall_rule resources at the same time
+	globalObjectLockList.lock(d.Id())
+	defer globalObjectLockList.unlock(d.Id())
+	err = client.UpdateFirewall(ctx, d.Id(), requestBody)
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
@@ -349,26 +405,77 @@ func convFirewallRuleSliceToInterfaceSlice(rules []gsclient.FirewallRuleProperti
 	return res
 }
 
-// convInterfaceSliceToFirewallRulesSlice converts slice of interface to slice of firewall rules
-func convInterfaceSliceToFirewallRulesSlice(interfaceRules []interface{}) []gsclient.FirewallRuleProperties {
-	var firewallRules []gsclient.FirewallRuleProperties
-	for _, value := range interfaceRules {
-		rule := value.(map[string]interface{})
-		fwRule := gsclient.FirewallRuleProperties{
-			DstPort: rule["dst_port"].(string),
-			SrcPort: rule["src_port"].(string),
-			SrcCidr: rule["src_cidr"].(string),
-			Action:  rule["action"].(string),
-			Comment: rule["comment"].(string),
-			DstCidr: rule["dst_cidr"].(string),
-			Order:   rule["order"].(int),
+// expandFirewallRules reads all firewall rules declared in a firewall resource.
+// Services and lists of CIDRs are expanded, default inbound rules are added to inbound rules.
+func expandFirewallRules(d *schema.ResourceData) (gsclient.FirewallRules, error) {
+	var fwRules gsclient.FirewallRules
+	mode := d.Get("default_inbound_rules").(string)
+	for ruleType, rulesPtr := range map[string]*[]gsclient.FirewallRuleProperties{
+		"rules_v4_in":  &fwRules.RulesV4In,
+		"rules_v4_out": &fwRules.RulesV4Out,
+		"rules_v6_in":  &fwRules.RulesV6In,
+		"rules_v6_out": &fwRules.RulesV6Out,
+	} {
+		rules, err := fwu.ConvInterfaceSliceToFirewallRules(d.Get(ruleType).([]interface{}))
+		if err != nil {
+			return fwRules, fmt.Errorf("%s: %v", ruleType, err)
 		}
-		if rule["protocol"].(string) == "tcp" {
-			fwRule.Protocol = gsclient.TCPTransport
-		} else if rule["protocol"].(string) == "udp" {
-			fwRule.Protocol = gsclient.UDPTransport
+		if ruleType == "rules_v4_in" || ruleType == "rules_v6_in" {
+			defaultRules, err := expandDefaultFirewallInboundRules(mode, d.Get(fmt.Sprintf("custom_default_%s", ruleType)).([]interface{}), ruleType == "rules_v6_in")
+			if err != nil {
+				return fwRules, fmt.Errorf("custom_default_%s: %v", ruleType, err)
+			}
+			rules = fwu.AddDefaultFirewallInboundRules(rules, defaultRules)
 		}
-		firewallRules = append(firewallRules, fwRule)
+		*rulesPtr = rules
 	}
-	return firewallRules
+	return fwRules, nil
+}
+
+// expandDefaultFirewallInboundRules returns the default inbound rules of a mode
+// as a slice of firewall rules. customRules is only used in "custom" mode.
+func expandDefaultFirewallInboundRules(mode string, customRules []interface{}, forIPv6 bool) ([]gsclient.FirewallRuleProperties, error) {
+	rules, err := fwu.ConvInterfaceSliceToFirewallRules(customRules)
+	if err != nil {
+		return nil, err
+	}
+	return fwu.DefaultFirewallInboundRules(mode, rules, forIPv6), nil
+}
+
+// flattenFirewallRulesWithConfig converts slice of firewall rules to slice of interface.
+// If the declared rules (which may reference services and lists of CIDRs) are expanded to
+// exactly the given rules, the declared rules are returned, so that no diff is shown.
+func flattenFirewallRulesWithConfig(rules []gsclient.FirewallRuleProperties, declaredRules []interface{}) []interface{} {
+	expandedRules, err := fwu.ConvInterfaceSliceToFirewallRules(declaredRules)
+	if err == nil && len(declaredRules) > 0 && isSameFirewallRuleList(rules, expandedRules) {
+		return declaredRules
+	}
+	return convFirewallRuleSliceToInterfaceSlice(rules)
+}
+
+// isSameFirewallRuleList checks if two lists of firewall rules contain the same rules
+// (including orders and comments) in the same sequence.
+func isSameFirewallRuleList(a, b []gsclient.FirewallRuleProperties) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !fwu.IsSameFirewallRuleContent(a[i], b[i]) || a[i].Order != b[i].Order || a[i].Comment != b[i].Comment {
+			return false
+		}
+	}
+	return true
+}
+
+// validateDefaultInboundRulesConfig validates that custom default inbound rules
+// are only set in "custom" mode, and that "custom" mode has at least one rule.
+func validateDefaultInboundRulesConfig(mode string, customRulesV4In, customRulesV6In []interface{}) error {
+	hasCustomRules := len(customRulesV4In) > 0 || len(customRulesV6In) > 0
+	if mode == fwu.DefaultInboundRulesCustom && !hasCustomRules {
+		return fmt.Errorf("default_inbound_rules \"%s\" requires at least one rule in custom_default_rules_v4_in or custom_default_rules_v6_in", fwu.DefaultInboundRulesCustom)
+	}
+	if mode != fwu.DefaultInboundRulesCustom && hasCustomRules {
+		return fmt.Errorf("custom_default_rules_v4_in and custom_default_rules_v6_in can only be set when default_inbound_rules is \"%s\"", fwu.DefaultInboundRulesCustom)
+	}
+	return nil
 }
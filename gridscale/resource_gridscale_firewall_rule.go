@@ -0,0 +1,379 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	fwu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/firewall-utils"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
+)
+
+var firewallRuleDirections = []string{"in", "out"}
+
+func resourceGridscaleFirewallRule() *schema.Resource {
+	ruleSchema := getFirewallRuleSchemaWithReferences()
+	// A rule resource represents exactly one rule, lists of CIDRs are not supported
+	delete(ruleSchema, "src_cidrs")
+	delete(ruleSchema, "dst_cidrs")
+	// A rule is identified by its order in the firewall
+	ruleSchema["order"].ForceNew = true
+	ruleSchema["firewall_uuid"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The UUID of the firewall the rule is added to.",
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.NoZeroValues,
+	}
+	ruleSchema["direction"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The direction of the traffic the rule applies to. Either 'in' or 'out'.",
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(firewallRuleDirections, false),
+	}
+	ruleSchema["ip_family"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Description:  "The IP family the rule applies to. Either 4 or 6.",
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IntInSlice([]int{4, 6}),
+	}
+	// The default inbound rules of a firewall are not stored in gridscale, they have
+	// to be passed from the firewall resource to keep them after the custom inbound rules.
+	ruleSchema["default_inbound_rules"] = &schema.Schema{
+		Type: schema.TypeString,
+		Description: `The default_inbound_rules of the firewall (e.g. gridscale_firewall.foo.default_inbound_rules).
+Only used for inbound rules.`,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(fwu.DefaultInboundRulesModes, false),
+	}
+	ruleSchema["custom_default_rules_in"] = &schema.Schema{
+		Type: schema.TypeList,
+		Description: `The custom default inbound rules of the firewall of the same ip_family
+(e.g. gridscale_firewall.foo.custom_default_rules_v4_in), if default_inbound_rules is "custom".`,
+		Optional:   true,
+		ConfigMode: schema.SchemaConfigModeAttr,
+		Elem: &schema.Resource{
+			Schema: getFirewallDefaultRuleSchema(),
+		},
+	}
+	return &schema.Resource{
+		Read:   resourceGridscaleFirewallRuleRead,
+		Create: resourceGridscaleFirewallRuleCreate,
+		Update: resourceGridscaleFirewallRuleUpdate,
+		Delete: resourceGridscaleFirewallRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGridscaleFirewallRuleImport,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			mode := d.Get("default_inbound_rules").(string)
+			customRules := d.Get("custom_default_rules_in").([]interface{})
+			if d.Get("direction").(string) == "out" && (mode != "" || len(customRules) > 0) {
+				return fmt.Errorf("default_inbound_rules and custom_default_rules_in can only be set for inbound rules")
+			}
+			return validateDefaultInboundRulesConfig(mode, customRules, nil)
+		},
+		Schema: ruleSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleFirewallRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// ID format: <firewall_uuid>/<ip_family>/<direction>/<order>
+	idParts := strings.Split(d.Id(), "/")
+	if len(idParts) != 4 {
+		return nil, fmt.Errorf("invalid firewall rule ID (%s), expected format: <firewall_uuid>/<ip_family>/<direction>/<order>", d.Id())
+	}
+	ipFamily, err := strconv.Atoi(idParts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ip_family in firewall rule ID (%s): %v", d.Id(), err)
+	}
+	order, err := strconv.Atoi(idParts[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid order in firewall rule ID (%s): %v", d.Id(), err)
+	}
+	if err = d.Set("firewall_uuid", idParts[0]); err != nil {
+		return nil, err
+	}
+	if err = d.Set("ip_family", ipFamily); err != nil {
+		return nil, err
+	}
+	if err = d.Set("direction", idParts[2]); err != nil {
+		return nil, err
+	}
+	if err = d.Set("order", order); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGridscaleFirewallRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("read firewall rule (%s) resource -", d.Id())
+	firewall, err := client.GetFirewall(context.Background(), d.Get("firewall_uuid").(string))
+	if err != nil {
+		if requestError, ok := err.(gsclient.RequestError); ok {
+			if requestError.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	rules := *getFirewallRulesByType(&firewall.Properties.Rules, getFirewallRuleType(d))
+	idx := findFirewallRuleByOrder(rules, d.Get("order").(int))
+	// If the rule is not found, it was removed outside of terraform
+	if idx == -1 {
+		log.Printf("[WARN] firewall rule (%s) not found, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	rule := flattenFirewallRuleProperties(rules[idx])
+	// Keep the declared service if it still matches protocol and dst_port of the rule
+	service := d.Get("service").(string)
+	if svc, ok := fwu.FirewallServices[service]; ok && svc.Protocol == rules[idx].Protocol && svc.DstPort == rules[idx].DstPort {
+		rule["protocol"] = ""
+		rule["dst_port"] = ""
+	} else {
+		service = ""
+	}
+	rule["service"] = service
+	for k, v := range rule {
+		if err = d.Set(k, v); err != nil {
+			return fmt.Errorf("%s error setting %s: %v", errorPrefix, k, err)
+		}
+	}
+	return nil
+}
+
+func resourceGridscaleFirewallRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	firewallUUID := d.Get("firewall_uuid").(string)
+	ruleType := getFirewallRuleType(d)
+	order := d.Get("order").(int)
+	errorPrefix := fmt.Sprintf("create firewall rule (%s, order %d) in firewall (%s) resource -", ruleType, order, firewallUUID)
+
+	rule, err := expandFirewallRuleProperties(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	defaultRules, err := expandFirewallRuleDefaultInboundRules(ruleType, d.Get("default_inbound_rules").(string), d.Get("custom_default_rules_in").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	err = modifyFirewallRulesSynchronously(ctx, client, firewallUUID, ruleType, defaultRules, defaultRules, func(rules []gsclient.FirewallRuleProperties) ([]gsclient.FirewallRuleProperties, error) {
+		if idx := findFirewallRuleByOrder(rules, order); idx != -1 {
+			return nil, fmt.Errorf("conflict: order %d is already used by another rule (%s) of %s", order, rules[idx].Comment, ruleType)
+		}
+		return append(rules, rule), nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	id := fmt.Sprintf("%s/%d/%s/%d", firewallUUID, d.Get("ip_family").(int), d.Get("direction").(string), order)
+	d.SetId(id)
+
+	log.Printf("The id for the new firewall rule has been set to %v", id)
+
+	return resourceGridscaleFirewallRuleRead(d, meta)
+}
+
+func resourceGridscaleFirewallRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("update firewall rule (%s) resource -", d.Id())
+	order := d.Get("order").(int)
+	ruleType := getFirewallRuleType(d)
+
+	rule, err := expandFirewallRuleProperties(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	// The existing default rules are identified by the previous mode of the firewall
+	oldMode, newMode := d.GetChange("default_inbound_rules")
+	oldCustomRules, newCustomRules := d.GetChange("custom_default_rules_in")
+	oldDefaultRules, err := expandFirewallRuleDefaultInboundRules(ruleType, oldMode.(string), oldCustomRules.([]interface{}))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	newDefaultRules, err := expandFirewallRuleDefaultInboundRules(ruleType, newMode.(string), newCustomRules.([]interface{}))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	err = modifyFirewallRulesSynchronously(ctx, client, d.Get("firewall_uuid").(string), ruleType, oldDefaultRules, newDefaultRules, func(rules []gsclient.FirewallRuleProperties) ([]gsclient.FirewallRuleProperties, error) {
+		idx := findFirewallRuleByOrder(rules, order)
+		if idx == -1 {
+			return nil, fmt.Errorf("rule with order %d not found", order)
+		}
+		rules[idx] = rule
+		return rules, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	return resourceGridscaleFirewallRuleRead(d, meta)
+}
+
+func resourceGridscaleFirewallRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("delete firewall rule (%s) resource -", d.Id())
+	order := d.Get("order").(int)
+	ruleType := getFirewallRuleType(d)
+
+	defaultRules, err := expandFirewallRuleDefaultInboundRules(ruleType, d.Get("default_inbound_rules").(string), d.Get("custom_default_rules_in").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	err = errHandler.SuppressHTTPErrorCodes(
+		modifyFirewallRulesSynchronously(ctx, client, d.Get("firewall_uuid").(string), ruleType, defaultRules, defaultRules, func(rules []gsclient.FirewallRuleProperties) ([]gsclient.FirewallRuleProperties, error) {
+			if idx := findFirewallRuleByOrder(rules, order); idx != -1 {
+				rules = append(rules[:idx], rules[idx+1:]...)
+			}
+			return rules, nil
+		}),
+		http.StatusNotFound,
+	)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+// modifyFirewallRulesSynchronously modifies the rules of a specific type (e.g. rules_v4_in) of a firewall
+// (read-modify-write). The firewall is locked during the modification, so that multiple
+// resources can modify the rules of the same firewall concurrently.
+// Existing default inbound rules (matching oldDefaultRules or newDefaultRules) are not passed
+// to `modify`, newDefaultRules are added after the modified custom rules. The modification fails
+// if the inbound rules of the firewall end with other default rules.
+func modifyFirewallRulesSynchronously(
+	ctx context.Context,
+	client *gsclient.Client,
+	firewallUUID, ruleType string,
+	oldDefaultRules, newDefaultRules []gsclient.FirewallRuleProperties,
+	modify func(rules []gsclient.FirewallRuleProperties) ([]gsclient.FirewallRuleProperties, error)) error {
+	globalObjectLockList.lock(firewallUUID)
+	defer globalObjectLockList.unlock(firewallUUID)
+
+	firewall, err := client.GetFirewall(ctx, firewallUUID)
+	if err != nil {
+		return err
+	}
+	fwRules := firewall.Properties.Rules
+	rulesPtr := getFirewallRulesByType(&fwRules, ruleType)
+
+	// Rules are rejected if their default inbound rules differ from the ones of the firewall,
+	// otherwise the default rules of the firewall would be replaced.
+	if strings.HasSuffix(ruleType, "_in") {
+		forIPv6 := ruleType == "rules_v6_in"
+		if !fwu.HasDefaultFirewallInboundRules(*rulesPtr, oldDefaultRules, forIPv6) {
+			if !fwu.HasDefaultFirewallInboundRules(*rulesPtr, newDefaultRules, forIPv6) {
+				return fmt.Errorf("the default inbound rules of %s of the firewall do not match default_inbound_rules and custom_default_rules_in of the rule", ruleType)
+			}
+			// The firewall has already been changed to the new default rules (e.g. in the same apply)
+			oldDefaultRules = newDefaultRules
+		}
+	}
+
+	rules, _ := fwu.SplitDefaultFirewallInboundRules(*rulesPtr, oldDefaultRules)
+	rules, err = modify(rules)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Order < rules[j].Order })
+	// Keep the default rules (if there are any) after the custom rules
+	*rulesPtr = fwu.AddDefaultFirewallInboundRules(rules, newDefaultRules)
+
+	return client.UpdateFirewall(ctx, firewallUUID, gsclient.FirewallUpdateRequest{
+		Rules: &fwRules,
+	})
+}
+
+// expandFirewallRuleDefaultInboundRules returns the default inbound rules of the firewall
+// which are kept after the rules of ruleType. Outbound rules have no default rules.
+func expandFirewallRuleDefaultInboundRules(ruleType, mode string, customRules []interface{}) ([]gsclient.FirewallRuleProperties, error) {
+	if ruleType != "rules_v4_in" && ruleType != "rules_v6_in" {
+		return nil, nil
+	}
+	return expandDefaultFirewallInboundRules(mode, customRules, ruleType == "rules_v6_in")
+}
+
+// getFirewallRuleType returns the type of firewall rules (e.g. rules_v4_in)
+// based on the ip_family and the direction of a firewall rule resource
+func getFirewallRuleType(d *schema.ResourceData) string {
+	return fmt.Sprintf("rules_v%d_%s", d.Get("ip_family").(int), d.Get("direction").(string))
+}
+
+// getFirewallRulesByType returns a pointer to the rules of a specific type in firewall rules
+func getFirewallRulesByType(fwRules *gsclient.FirewallRules, ruleType string) *[]gsclient.FirewallRuleProperties {
+	switch ruleType {
+	case "rules_v4_out":
+		return &fwRules.RulesV4Out
+	case "rules_v6_in":
+		return &fwRules.RulesV6In
+	case "rules_v6_out":
+		return &fwRules.RulesV6Out
+	default:
+		return &fwRules.RulesV4In
+	}
+}
+
+// findFirewallRuleByOrder returns the index of the rule with a specific order,
+// returns -1 if there is no rule with the order
+func findFirewallRuleByOrder(rules []gsclient.FirewallRuleProperties, order int) int {
+	for i, rule := range rules {
+		if rule.Order == order {
+			return i
+		}
+	}
+	return -1
+}
+
+// expandFirewallRuleProperties converts the firewall rule resource data to gsclient.FirewallRuleProperties.
+// A referenced service is resolved to protocol and dst_port.
+func expandFirewallRuleProperties(d *schema.ResourceData) (gsclient.FirewallRuleProperties, error) {
+	rule := gsclient.FirewallRuleProperties{
+		DstPort: d.Get("dst_port").(string),
+		SrcPort: d.Get("src_port").(string),
+		SrcCidr: d.Get("src_cidr").(string),
+		Action:  d.Get("action").(string),
+		Comment: d.Get("comment").(string),
+		DstCidr: d.Get("dst_cidr").(string),
+		Order:   d.Get("order").(int),
+	}
+	if d.Get("protocol").(string) == "tcp" {
+		rule.Protocol = gsclient.TCPTransport
+	} else if d.Get("protocol").(string) == "udp" {
+		rule.Protocol = gsclient.UDPTransport
+	}
+	rules, err := fwu.ExpandFirewallRule(rule, d.Get("service").(string), nil, nil)
+	if err != nil {
+		return rule, err
+	}
+	return rules[0], nil
+}
@@ -0,0 +1,275 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func TestAccResourceGridscaleFirewallRule_Basic(t *testing.T) {
+	var object gsclient.Firewall
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleFirewallDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleFirewallRuleConfig_basic(name, "22"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleFirewallExists("gridscale_firewall.foo", &object),
+					testAccCheckResourceGridscaleFirewallRuleExists("gridscale_firewall_rule.ssh"),
+					testAccCheckResourceGridscaleFirewallRuleExists("gridscale_firewall_rule.https"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall_rule.ssh", "dst_port", "22"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleFirewallRuleConfig_basic(name, "2222"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleFirewallRuleExists("gridscale_firewall_rule.ssh"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall_rule.ssh", "dst_port", "2222"),
+				),
+			},
+			{
+				ResourceName:      "gridscale_firewall_rule.https",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscaleFirewallRule_CustomDefaultInboundRules(t *testing.T) {
+	var object gsclient.Firewall
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleFirewallDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleFirewallRuleConfig_custom_default_rules(name, "22"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleFirewallExists("gridscale_firewall.foo", &object),
+					testAccCheckResourceGridscaleFirewallRuleExists("gridscale_firewall_rule.ssh"),
+					testAccCheckResourceGridscaleFirewallRuleDefaultInboundRules("gridscale_firewall.foo", 3, "drop all"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleFirewallRuleConfig_custom_default_rules(name, "2222"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleFirewallRuleExists("gridscale_firewall_rule.ssh"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall_rule.ssh", "dst_port", "2222"),
+					testAccCheckResourceGridscaleFirewallRuleDefaultInboundRules("gridscale_firewall.foo", 3, "drop all"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscaleFirewallRule_DefaultInboundRulesMismatch(t *testing.T) {
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleFirewallDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckResourceGridscaleFirewallRuleConfig_default_rules_mismatch(name),
+				ExpectError: regexp.MustCompile("do not match default_inbound_rules"),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleFirewallRuleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No object ID is set")
+		}
+
+		client := testAccProvider.Meta().(*gsclient.Client)
+
+		foundObject, err := client.GetFirewall(context.Background(), rs.Primary.Attributes["firewall_uuid"])
+
+		if err != nil {
+			return err
+		}
+
+		ruleType := fmt.Sprintf("rules_v%s_%s", rs.Primary.Attributes["ip_family"], rs.Primary.Attributes["direction"])
+		for _, rule := range *getFirewallRulesByType(&foundObject.Properties.Rules, ruleType) {
+			if fmt.Sprint(rule.Order) == rs.Primary.Attributes["order"] {
+				return nil
+			}
+		}
+		return fmt.Errorf("Object not found")
+	}
+}
+
+func testAccCheckResourceGridscaleFirewallRuleConfig_basic(name, sshPort string) string {
+	return fmt.Sprintf(`
+resource "gridscale_firewall" "foo" {
+  name   = "%s"
+  rules_v4_in {
+	order = 0
+	protocol = "tcp"
+	action = "accept"
+	dst_port = "80"
+	comment = "baseline"
+  }
+  lifecycle {
+	ignore_changes = [rules_v4_in]
+  }
+}
+
+resource "gridscale_firewall_rule" "ssh" {
+  firewall_uuid = gridscale_firewall.foo.id
+  direction = "in"
+  ip_family = 4
+  order = 10
+  protocol = "tcp"
+  action = "accept"
+  dst_port = "%s"
+  comment = "ssh"
+}
+
+resource "gridscale_firewall_rule" "https" {
+  firewall_uuid = gridscale_firewall.foo.id
+  direction = "in"
+  ip_family = 4
+  order = 20
+  protocol = "tcp"
+  action = "accept"
+  dst_port = "443"
+  comment = "https"
+}
+`, name, sshPort)
+}
+
+// testAccCheckResourceGridscaleFirewallRuleDefaultInboundRules checks that the IPv4 inbound rules
+// of a firewall contain exactly `count` rules, and that the last one is the default rule with `comment`.
+func testAccCheckResourceGridscaleFirewallRuleDefaultInboundRules(n string, count int, comment string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*gsclient.Client)
+
+		foundObject, err := client.GetFirewall(context.Background(), rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		rules := foundObject.Properties.Rules.RulesV4In
+		if len(rules) != count {
+			return fmt.Errorf("Expected %d inbound rules, got %d", count, len(rules))
+		}
+		last := rules[0]
+		for _, rule := range rules {
+			if rule.Order > last.Order {
+				last = rule
+			}
+		}
+		if last.Comment != comment {
+			return fmt.Errorf("Expected the last inbound rule to be %q, got %q", comment, last.Comment)
+		}
+		return nil
+	}
+}
+
+func testAccCheckResourceGridscaleFirewallRuleConfig_custom_default_rules(name, sshPort string) string {
+	return fmt.Sprintf(`
+resource "gridscale_firewall" "foo" {
+  name   = "%s"
+  default_inbound_rules = "custom"
+  rules_v4_in {
+	order = 0
+	protocol = "tcp"
+	action = "accept"
+	dst_port = "80"
+	comment = "baseline"
+  }
+  custom_default_rules_v4_in {
+	protocol = "tcp"
+	action = "drop"
+	dst_port = "1:65535"
+	comment = "drop all"
+  }
+  lifecycle {
+	ignore_changes = [rules_v4_in]
+  }
+}
+
+resource "gridscale_firewall_rule" "ssh" {
+  firewall_uuid = gridscale_firewall.foo.id
+  direction = "in"
+  ip_family = 4
+  order = 10
+  protocol = "tcp"
+  action = "accept"
+  dst_port = "%s"
+  comment = "ssh"
+  default_inbound_rules = gridscale_firewall.foo.default_inbound_rules
+  custom_default_rules_in = gridscale_firewall.foo.custom_default_rules_v4_in
+}
+`, name, sshPort)
+}
+
+func testAccCheckResourceGridscaleFirewallRuleConfig_default_rules_mismatch(name string) string {
+	return fmt.Sprintf(`
+resource "gridscale_firewall" "foo" {
+  name   = "%s"
+  default_inbound_rules = "custom"
+  rules_v4_in {
+	order = 0
+	protocol = "tcp"
+	action = "accept"
+	dst_port = "80"
+	comment = "baseline"
+  }
+  custom_default_rules_v4_in {
+	protocol = "tcp"
+	action = "drop"
+	dst_port = "1:65535"
+	comment = "drop all"
+  }
+  lifecycle {
+	ignore_changes = [rules_v4_in]
+  }
+}
+
+resource "gridscale_firewall_rule" "ssh" {
+  firewall_uuid = gridscale_firewall.foo.id
+  direction = "in"
+  ip_family = 4
+  order = 10
+  protocol = "tcp"
+  action = "accept"
+  dst_port = "22"
+  comment = "ssh"
+}
+`, name)
+}
@@ -27,6 +27,12 @@ func TestAccResourceGridscaleFirewall_Basic(t *testing.T) {
 					testAccCheckResourceGridscaleFirewallExists("gridscale_firewall.foo", &object),
 					resource.TestCheckResourceAttr(
 						"gridscale_firewall.foo", "name", name),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall.foo", "rules_v4_in.#", "1"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall.foo", "default_rules_v4_in.#", "5"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall.foo", "default_rules_v6_in.#", "5"),
 				),
 			},
 			{
@@ -37,6 +43,20 @@ func TestAccResourceGridscaleFirewall_Basic(t *testing.T) {
 						"gridscale_firewall.foo", "name", "newname"),
 				),
 			},
+			{
+				Config: testAccCheckResourceGridscaleFirewallConfig_default_rules_update(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleFirewallExists("gridscale_firewall.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall.foo", "rules_v4_in.#", "1"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall.foo", "default_rules_v4_in.#", "1"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall.foo", "default_rules_v4_in.0.order", "2"),
+					resource.TestCheckResourceAttr(
+						"gridscale_firewall.foo", "default_rules_v6_in.#", "0"),
+				),
+			},
 		},
 	})
 }
@@ -141,3 +161,25 @@ resource "gridscale_firewall" "foo" {
 }
 `)
 }
+
+func testAccCheckResourceGridscaleFirewallConfig_default_rules_update() string {
+	return fmt.Sprintf(`
+resource "gridscale_firewall" "foo" {
+  name   = "newname"
+  default_inbound_rules = "custom"
+  rules_v4_in {
+	order = 1
+	protocol = "tcp"
+	action = "accept"
+	dst_port = "32768:65535"
+	comment = "Highports TCP"
+  }
+  custom_default_rules_v4_in {
+	protocol = "tcp"
+	action = "drop"
+	dst_port = "1:65535"
+	comment = "drop all"
+  }
+}
+`)
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gridscale/gsclient-go/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
 )
 
@@ -29,11 +31,80 @@ func resourceGridscaleISOImage() *schema.Resource {
 				Required:    true,
 			},
 			"source_url": {
+				Type:         schema.TypeString,
+				Description:  "Contains the source URL of the ISO image that it was originally fetched from.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"source_url", "source_file"},
+			},
+			"source_file": {
+				Type:         schema.TypeString,
+				Description:  "Path of a local ISO file. It is uploaded to the object storage defined in object_storage, and the ISO image is fetched from there.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"source_url", "source_file"},
+				RequiredWith: []string{"object_storage"},
+			},
+			"source_file_sha256": {
 				Type:        schema.TypeString,
-				Description: "Contains the source URL of the ISO image that it was originally fetched from.",
-				Required:    true,
+				Description: "SHA256 checksum of source_file. The ISO image is uploaded and created again if it changes.",
+				Computed:    true,
 				ForceNew:    true,
 			},
+			"object_storage": {
+				Type:         schema.TypeList,
+				Description:  "The object storage which source_file is uploaded to.",
+				Optional:     true,
+				MaxItems:     1,
+				ForceNew:     true,
+				RequiredWith: []string{"source_file"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:         schema.TypeString,
+							Description:  "Host of object storage. Must be of URL type. E.g: https://gos3.io",
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "https://gos3.io",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"access_key": {
+							Type:        schema.TypeString,
+							Description: "Access key",
+							Required:    true,
+							ForceNew:    true,
+							Sensitive:   true,
+						},
+						"secret_key": {
+							Type:        schema.TypeString,
+							Description: "Secret key",
+							Required:    true,
+							ForceNew:    true,
+							Sensitive:   true,
+						},
+						"bucket": {
+							Type:        schema.TypeString,
+							Description: "Bucket name",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"object": {
+							Type:        schema.TypeString,
+							Description: "Name of the uploaded file (include file path)",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"delete_after_import": {
+							Type:        schema.TypeBool,
+							Description: "Delete the uploaded file from the object storage after the ISO image has been created.",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
 			"server": {
 				Type:        schema.TypeSet,
 				Description: "The information about servers which are related to this ISO image.",
@@ -136,6 +207,33 @@ func resourceGridscaleISOImage() *schema.Resource {
 			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Track the checksum of the local file, so that the ISO image is replaced when the file changes
+			sourceFile := d.Get("source_file").(string)
+			if sourceFile == "" || !d.NewValueKnown("source_file") {
+				return nil
+			}
+			checksum, err := fileSHA256(sourceFile)
+			if err != nil {
+				// The file is only required to create the ISO image. Without the file (e.g. in a clean checkout),
+				// the stored checksum is kept.
+				if os.IsNotExist(err) && d.Id() != "" && !d.HasChange("source_file") {
+					log.Printf("[DEBUG] source_file %s of ISO image %s does not exist, its checksum is not checked", sourceFile, d.Id())
+					return nil
+				}
+				return fmt.Errorf("error reading source_file %s: %v", sourceFile, err)
+			}
+			if d.Get("source_file_sha256").(string) == checksum {
+				return nil
+			}
+			if err = d.SetNew("source_file_sha256", checksum); err != nil {
+				return err
+			}
+			if d.Id() != "" {
+				return d.ForceNew("source_file_sha256")
+			}
+			return nil
+		},
 	}
 }
 
@@ -156,7 +254,12 @@ func resourceGridscaleISOImageRead(d *schema.ResourceData, meta interface{}) err
 	if err = d.Set("name", props.Name); err != nil {
 		return fmt.Errorf("%s error setting name: %v", errorPrefix, err)
 	}
-	if err = d.Set("source_url", props.SourceURL); err != nil {
+	// The source URL of an uploaded source_file is a presigned URL including credentials, it is not stored
+	sourceURL := props.SourceURL
+	if d.Get("source_file").(string) != "" {
+		sourceURL = ""
+	}
+	if err = d.Set("source_url", sourceURL); err != nil {
 		return fmt.Errorf("%s error setting source_url: %v", errorPrefix, err)
 	}
 	if err = d.Set("location_uuid", props.LocationUUID); err != nil {
@@ -231,6 +334,25 @@ func resourceGridscaleISOImageCreate(d *schema.ResourceData, meta interface{}) e
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
+
+	// Upload a local file to the object storage, gridscale fetches the ISO image from a presigned URL
+	sourceFile := d.Get("source_file").(string)
+	var objStorage map[string]interface{}
+	if sourceFile != "" {
+		objStorage = d.Get("object_storage").([]interface{})[0].(map[string]interface{})
+		checksum, err := fileSHA256(sourceFile)
+		if err != nil {
+			return fmt.Errorf("error reading source_file %s: %v", sourceFile, err)
+		}
+		if err = d.Set("source_file_sha256", checksum); err != nil {
+			return fmt.Errorf("error setting source_file_sha256: %v", err)
+		}
+		requestBody.SourceURL, err = uploadISOImageToObjectStorage(ctx, sourceFile, objStorage)
+		if err != nil {
+			return err
+		}
+	}
+
 	response, err := client.CreateISOImage(ctx, requestBody)
 	if err != nil {
 		return err
@@ -240,6 +362,16 @@ func resourceGridscaleISOImageCreate(d *schema.ResourceData, meta interface{}) e
 
 	log.Printf("The id for the new ISO image has been set to %v", response.ObjectUUID)
 
+	if objStorage != nil && objStorage["delete_after_import"].(bool) {
+		// The uploaded file can only be deleted after gridscale has fetched it
+		if err = waitForISOImageActive(ctx, client, response.ObjectUUID); err != nil {
+			return err
+		}
+		if err = deleteISOImageStagingObject(ctx, objStorage); err != nil {
+			return fmt.Errorf("error deleting %s/%s from object storage: %v", objStorage["bucket"], objStorage["object"], err)
+		}
+	}
+
 	return resourceGridscaleISOImageRead(d, meta)
 }
 
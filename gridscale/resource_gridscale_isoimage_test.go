@@ -3,6 +3,8 @@ package gridscale
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -41,6 +43,43 @@ func TestAccResourceGridscaleISOImage_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscaleISOImage_SourceFile(t *testing.T) {
+	var object gsclient.ISOImage
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+	sourceFile := filepath.Join(t.TempDir(), "rescue.iso")
+	writeSourceFile := func(content string) {
+		if err := os.WriteFile(sourceFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeSourceFile("rescue v1")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleISOImageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleISOImageConfig_sourceFile(name, sourceFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleISOImageExists("gridscale_isoimage.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_isoimage.foo", "source_file_sha256", "2308af2189854c99e3da70aaa2eb9890291ea6ecf4a47499d409f9f3c426f3c5"),
+				),
+			},
+			{
+				PreConfig: func() { writeSourceFile("rescue v2") },
+				Config:    testAccCheckResourceGridscaleISOImageConfig_sourceFile(name, sourceFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleISOImageExists("gridscale_isoimage.foo", &object),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_isoimage.foo", "source_file_sha256"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscaleISOImageExists(n string, object *gsclient.ISOImage) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -121,3 +160,31 @@ resource "gridscale_isoimage" "foo" {
 }
 `)
 }
+
+func testAccCheckResourceGridscaleISOImageConfig_sourceFile(name, sourceFile string) string {
+	return fmt.Sprintf(`
+resource "gridscale_object_storage_accesskey" "foo" {
+  timeouts {
+    create="10m"
+  }
+}
+
+resource "gridscale_object_storage_bucket" "foo" {
+  access_key = gridscale_object_storage_accesskey.foo.access_key
+  secret_key = gridscale_object_storage_accesskey.foo.secret_key
+  bucket_name = "%s"
+}
+
+resource "gridscale_isoimage" "foo" {
+  name   = "%s"
+  source_file = "%s"
+  object_storage {
+    access_key = gridscale_object_storage_accesskey.foo.access_key
+    secret_key = gridscale_object_storage_accesskey.foo.secret_key
+    bucket = gridscale_object_storage_bucket.foo.bucket_name
+    object = "isoimages/rescue.iso"
+    delete_after_import = true
+  }
+}
+`, name, name, sourceFile)
+}
@@ -23,7 +23,7 @@ const (
 )
 
 func resourceGridscaleK8s() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscaleK8sCreate,
 		Read:   resourceGridscaleK8sRead,
 		Delete: resourceGridscaleK8sDelete,
@@ -221,6 +221,8 @@ func resourceGridscaleK8s() *schema.Resource {
 			Delete: schema.DefaultTimeout(45 * time.Minute),
 		},
 	}
+	addPaaSCredentialRotation(resource, "kubeconfig")
+	return resource
 }
 
 func resourceGridscaleK8sRead(d *schema.ResourceData, meta interface{}) error {
@@ -439,6 +441,11 @@ func resourceGridscaleK8sUpdate(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
+	if d.HasChange("rotate_credentials") {
+		if err = renewPaaSCredentials(ctx, client, d.Id()); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
 	return resourceGridscaleK8sRead(d, meta)
 }
 
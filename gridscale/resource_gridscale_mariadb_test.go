@@ -2,6 +2,7 @@ package gridscale
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/gridscale/gsclient-go/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -39,6 +40,33 @@ func TestAccResourceGridscaleMariaDB_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscaleMariaDB_Autoscaling(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("mariadb-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckResourceGridscaleMariaDBConfig_autoscaling(name, 1000),
+				ExpectError: regexp.MustCompile("Invalid 'autoscaling.0.cores_max' value"),
+			},
+			{
+				Config: testAccCheckResourceGridscaleMariaDBConfig_autoscaling(name, 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_mariadb.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_mariadb.test", "autoscaling.0.cores_max", "4"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_mariadb.test", "autoscaling.0.cores_min"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscaleMariaDBConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "gridscale_mariadb" "test" {
@@ -65,3 +93,16 @@ resource "gridscale_mariadb" "test" {
 }
 `)
 }
+
+func testAccCheckResourceGridscaleMariaDBConfig_autoscaling(name string, coresMax int) string {
+	return fmt.Sprintf(`
+resource "gridscale_mariadb" "test" {
+	name = "%s"
+	release = "10.5"
+	performance_class = "standard"
+	autoscaling {
+		cores_max = %d
+	}
+}
+`, name, coresMax)
+}
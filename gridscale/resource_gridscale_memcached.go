@@ -19,7 +19,7 @@ import (
 const memcachedTemplateFlavourName = "memcached"
 
 func resourceGridscaleMemcached() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscaleMemcachedCreate,
 		Read:   resourceGridscaleMemcachedRead,
 		Delete: resourceGridscaleMemcachedDelete,
@@ -56,6 +56,9 @@ func resourceGridscaleMemcached() *schema.Resource {
 				}
 				return errors.New(errMess)
 			}
+			if err := validatePaaSAutoscaling(d, chosenTemplate); err != nil {
+				return err
+			}
 			return validateMemcachedParameters(d, chosenTemplate)
 		},
 		Schema: map[string]*schema.Schema{
@@ -68,7 +71,7 @@ func resourceGridscaleMemcached() *schema.Resource {
 			"release": {
 				Type: schema.TypeString,
 				Description: `The Memcached release of this instance.\n
-				For convenience, please use gscloud https://github.com/gridscale/gscloud to get the list of available Memcached service releases.`,
+				Use the gridscale_paas_templates data source (or gscloud https://github.com/gridscale/gscloud) to get the list of available Memcached service releases.`,
 				Required:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
@@ -175,6 +178,9 @@ func resourceGridscaleMemcached() *schema.Resource {
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 	}
+	addPaaSAutoscalingSchema(resource.Schema)
+	addPaaSCredentialRotation(resource, "username", "password")
+	return resource
 }
 
 func resourceGridscaleMemcachedRead(d *schema.ResourceData, meta interface{}) error {
@@ -253,6 +259,11 @@ func resourceGridscaleMemcachedRead(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
+	//Get autoscaling limits
+	if err = setPaaSAutoscaling(client, d, props); err != nil {
+		return fmt.Errorf("%s error setting autoscaling: %v", errorPrefix, err)
+	}
+
 	//Set labels
 	if err = d.Set("labels", props.Labels); err != nil {
 		return fmt.Errorf("%s error setting labels: %v", errorPrefix, err)
@@ -307,13 +318,7 @@ func resourceGridscaleMemcachedCreate(d *schema.ResourceData, meta interface{})
 	if secZoneUUIDInf, ok := d.GetOk("security_zone_uuid"); ok && !isNetworkSet {
 		requestBody.PaaSSecurityZoneUUID = secZoneUUIDInf.(string)
 	}
-	if val, ok := d.GetOk("max_core_count"); ok {
-		limits := []gsclient.ResourceLimit{
-			{
-				Resource: "cores",
-				Limit:    val.(int),
-			},
-		}
+	if limits := expandPaaSAutoscalingLimits(d, true); len(limits) > 0 {
 		requestBody.ResourceLimits = limits
 	}
 
@@ -352,13 +357,7 @@ func resourceGridscaleMemcachedUpdate(d *schema.ResourceData, meta interface{})
 		requestBody.PaaSServiceTemplateUUID = templateUUID
 	}
 
-	if val, ok := d.GetOk("max_core_count"); ok {
-		limits := []gsclient.ResourceLimit{
-			{
-				Resource: "cores",
-				Limit:    val.(int),
-			},
-		}
+	if limits := expandPaaSAutoscalingLimits(d, true); len(limits) > 0 {
 		requestBody.ResourceLimits = limits
 	}
 
@@ -368,6 +367,11 @@ func resourceGridscaleMemcachedUpdate(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
+	if d.HasChange("rotate_credentials") {
+		if err = renewPaaSCredentials(ctx, client, d.Id()); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
 	return resourceGridscaleMemcachedRead(d, meta)
 }
 
@@ -20,7 +20,7 @@ import (
 const mysqlTemplateFlavourName = "mysql"
 
 func resourceGridscaleMySQL() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscaleMySQLCreate,
 		Read:   resourceGridscaleMySQLRead,
 		Delete: resourceGridscaleMySQLDelete,
@@ -57,6 +57,15 @@ func resourceGridscaleMySQL() *schema.Resource {
 				}
 				return errors.New(errMess)
 			}
+			if err := customizePaaSTemplateChangeDiff(d, paasTemplates, chosenTemplate); err != nil {
+				return err
+			}
+			if err := validatePaaSAutoscaling(d, chosenTemplate); err != nil {
+				return err
+			}
+			if err := validatePaaSBackup(ctx, d, client, chosenTemplate); err != nil {
+				return err
+			}
 			return validateMySQLParameters(d, chosenTemplate)
 		},
 		Schema: map[string]*schema.Schema{
@@ -69,7 +78,7 @@ func resourceGridscaleMySQL() *schema.Resource {
 			"release": {
 				Type: schema.TypeString,
 				Description: `The MySQL release of this instance.\n
-				For convenience, please use gscloud https://github.com/gridscale/gscloud to get the list of available MySQL service releases.`,
+				Use the gridscale_paas_templates data source (or gscloud https://github.com/gridscale/gscloud) to get the list of available MySQL service releases.`,
 				Required:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
@@ -236,6 +245,11 @@ func resourceGridscaleMySQL() *schema.Resource {
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 	}
+	addPaaSAutoscalingSchema(resource.Schema)
+	addPaaSTemplateChangeSchema(resource.Schema)
+	addPaaSBackupSchema(resource.Schema)
+	addPaaSCredentialRotation(resource, "username", "password")
+	return resource
 }
 
 func resourceGridscaleMySQLRead(d *schema.ResourceData, meta interface{}) error {
@@ -345,6 +359,16 @@ func resourceGridscaleMySQLRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	//Get autoscaling limits
+	if err = setPaaSAutoscaling(client, d, props); err != nil {
+		return fmt.Errorf("%s error setting autoscaling: %v", errorPrefix, err)
+	}
+
+	//Get backup settings
+	if err = setPaaSBackup(d, props); err != nil {
+		return fmt.Errorf("%s error setting s3_backup: %v", errorPrefix, err)
+	}
+
 	//Set labels
 	if err = d.Set("labels", props.Labels); err != nil {
 		return fmt.Errorf("%s error setting labels: %v", errorPrefix, err)
@@ -399,13 +423,7 @@ func resourceGridscaleMySQLCreate(d *schema.ResourceData, meta interface{}) erro
 	if secZoneUUIDInf, ok := d.GetOk("security_zone_uuid"); ok && !isNetworkSet {
 		requestBody.PaaSSecurityZoneUUID = secZoneUUIDInf.(string)
 	}
-	if val, ok := d.GetOk("max_core_count"); ok {
-		limits := []gsclient.ResourceLimit{
-			{
-				Resource: "cores",
-				Limit:    val.(int),
-			},
-		}
+	if limits := expandPaaSAutoscalingLimits(d, true); len(limits) > 0 {
 		requestBody.ResourceLimits = limits
 	}
 
@@ -420,6 +438,7 @@ func resourceGridscaleMySQLCreate(d *schema.ResourceData, meta interface{}) erro
 	params["mysql_default_time_zone"] = d.Get("mysql_default_time_zone")
 	params["mysql_query_cache_limit"] = d.Get("mysql_query_cache_limit")
 	params["mysql_max_allowed_packet"] = d.Get("mysql_max_allowed_packet")
+	expandPaaSBackupParameters(d, params, true)
 	requestBody.Parameters = params
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
@@ -457,13 +476,7 @@ func resourceGridscaleMySQLUpdate(d *schema.ResourceData, meta interface{}) erro
 		requestBody.PaaSServiceTemplateUUID = templateUUID
 	}
 
-	if val, ok := d.GetOk("max_core_count"); ok {
-		limits := []gsclient.ResourceLimit{
-			{
-				Resource: "cores",
-				Limit:    val.(int),
-			},
-		}
+	if limits := expandPaaSAutoscalingLimits(d, true); len(limits) > 0 {
 		requestBody.ResourceLimits = limits
 	}
 
@@ -478,6 +491,7 @@ func resourceGridscaleMySQLUpdate(d *schema.ResourceData, meta interface{}) erro
 	params["mysql_default_time_zone"] = d.Get("mysql_default_time_zone")
 	params["mysql_query_cache_limit"] = d.Get("mysql_query_cache_limit")
 	params["mysql_max_allowed_packet"] = d.Get("mysql_max_allowed_packet")
+	expandPaaSBackupParameters(d, params, false)
 	requestBody.Parameters = params
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
@@ -486,6 +500,11 @@ func resourceGridscaleMySQLUpdate(d *schema.ResourceData, meta interface{}) erro
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
+	if d.HasChange("rotate_credentials") {
+		if err = renewPaaSCredentials(ctx, client, d.Id()); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
 	return resourceGridscaleMySQLRead(d, meta)
 }
 
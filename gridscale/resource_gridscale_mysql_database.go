@@ -0,0 +1,155 @@
+package gridscale
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sqlu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/sql-utils"
+)
+
+func resourceGridscaleMySQLDatabase() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscaleMySQLDatabaseCreate,
+		Read:   resourceGridscaleMySQLDatabaseRead,
+		Update: resourceGridscaleMySQLDatabaseUpdate,
+		Delete: resourceGridscaleMySQLDatabaseDelete,
+		Schema: map[string]*schema.Schema{
+			"service": getMySQLConnectionSchema(),
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the database.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"default_character_set": {
+				Type:         schema.TypeString,
+				Description:  "Default character set of the database. Default: the default of the service.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"default_collation": {
+				Type:         schema.TypeString,
+				Description:  "Default collation of the database. Default: the default of the character set.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleMySQLDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("read MySQL database (%s) resource -", d.Id())
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	var characterSet, collation string
+	err = db.QueryRowContext(context.Background(),
+		"SELECT DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?", d.Id(),
+	).Scan(&characterSet, &collation)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] MySQL database %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	if err = d.Set("name", d.Id()); err != nil {
+		return fmt.Errorf("%s error setting name: %v", errorPrefix, err)
+	}
+	if err = d.Set("default_character_set", characterSet); err != nil {
+		return fmt.Errorf("%s error setting default_character_set: %v", errorPrefix, err)
+	}
+	if err = d.Set("default_collation", collation); err != nil {
+		return fmt.Errorf("%s error setting default_collation: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscaleMySQLDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	errorPrefix := fmt.Sprintf("create MySQL database (%s) resource -", name)
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	query := fmt.Sprintf("CREATE DATABASE %s%s", sqlu.QuoteMySQLIdentifier(name), getMySQLDatabaseOptions(d))
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	d.SetId(name)
+
+	log.Printf("The id for the new MySQL database has been set to %v", name)
+	return resourceGridscaleMySQLDatabaseRead(d, meta)
+}
+
+func resourceGridscaleMySQLDatabaseUpdate(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("update MySQL database (%s) resource -", d.Id())
+	if d.HasChanges("default_character_set", "default_collation") {
+		db, err := openMySQLConnection(d)
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+		query := fmt.Sprintf("ALTER DATABASE %s%s", sqlu.QuoteMySQLIdentifier(d.Id()), getMySQLDatabaseOptions(d))
+		if _, err = db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
+	return resourceGridscaleMySQLDatabaseRead(d, meta)
+}
+
+func resourceGridscaleMySQLDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("delete MySQL database (%s) resource -", d.Id())
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	if _, err = db.ExecContext(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s", sqlu.QuoteMySQLIdentifier(d.Id()))); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+// getMySQLDatabaseOptions returns the options of CREATE DATABASE and ALTER DATABASE.
+// Character sets and collations are identifiers, so they are quoted as well. The collation of
+// a changed character set is left to the service, unless the collation is changed as well.
+func getMySQLDatabaseOptions(d *schema.ResourceData) string {
+	var options string
+	if characterSet, ok := d.GetOk("default_character_set"); ok {
+		options += fmt.Sprintf(" CHARACTER SET %s", sqlu.QuoteMySQLIdentifier(characterSet.(string)))
+	}
+	if collation, ok := d.GetOk("default_collation"); ok && (d.HasChange("default_collation") || !d.HasChange("default_character_set")) {
+		options += fmt.Sprintf(" COLLATE %s", sqlu.QuoteMySQLIdentifier(collation.(string)))
+	}
+	return options
+}
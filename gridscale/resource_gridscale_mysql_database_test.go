@@ -0,0 +1,52 @@
+package gridscale
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscaleMySQLDatabase_Basic(t *testing.T) {
+	serviceURL := testAccMySQLServiceURL(t)
+	name := fmt.Sprintf("db_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckGridscaleMySQLObjectDestroyCheck(serviceURL, "gridscale_mysql_database",
+			"SELECT COUNT(*) FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?", "name"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleMySQLDatabaseConfig_basic(serviceURL, name, "utf8mb4", "utf8mb4_general_ci"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql_database.foo", "name", name),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql_database.foo", "default_character_set", "utf8mb4"),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql_database.foo", "default_collation", "utf8mb4_general_ci"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleMySQLDatabaseConfig_basic(serviceURL, name, "utf8mb4", "utf8mb4_bin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql_database.foo", "default_collation", "utf8mb4_bin"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleMySQLDatabaseConfig_basic(serviceURL *url.URL, name, characterSet, collation string) string {
+	return fmt.Sprintf(`
+resource "gridscale_mysql_database" "foo" {%s
+	name = "%s"
+	default_character_set = "%s"
+	default_collation = "%s"
+}
+`, testAccMySQLServiceBlock(serviceURL), name, characterSet, collation)
+}
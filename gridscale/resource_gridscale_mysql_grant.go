@@ -0,0 +1,182 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sqlu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/sql-utils"
+)
+
+func resourceGridscaleMySQLGrant() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscaleMySQLGrantCreate,
+		Read:   resourceGridscaleMySQLGrantRead,
+		Update: resourceGridscaleMySQLGrantUpdate,
+		Delete: resourceGridscaleMySQLGrantDelete,
+		Schema: map[string]*schema.Schema{
+			"service": getMySQLConnectionSchema(),
+			"user": {
+				Type:         schema.TypeString,
+				Description:  "Name of the user the privileges are granted to.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"host": {
+				Type:         schema.TypeString,
+				Description:  "Host of the user the privileges are granted to.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "%",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"database": {
+				Type:         schema.TypeString,
+				Description:  "Database the privileges are granted on.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"privileges": {
+				Type:        schema.TypeSet,
+				Description: fmt.Sprintf("Privileges which are granted on all tables of the database. Valid values are: %s.", strings.Join(sqlu.MySQLDatabasePrivileges, ", ")),
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(sqlu.MySQLDatabasePrivileges, false),
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleMySQLGrantRead(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("read MySQL grant (%s) resource -", d.Id())
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(context.Background(),
+		"SELECT PRIVILEGE_TYPE FROM information_schema.SCHEMA_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ?",
+		sqlu.MySQLGrantee(d.Get("user").(string), d.Get("host").(string)), sqlu.EscapeMySQLDatabasePattern(d.Get("database").(string)),
+	)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer rows.Close()
+	var privileges []string
+	for rows.Next() {
+		var privilege string
+		if err = rows.Scan(&privilege); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		privileges = append(privileges, privilege)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	if len(privileges) == 0 {
+		log.Printf("[WARN] MySQL grant %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("privileges", privileges); err != nil {
+		return fmt.Errorf("%s error setting privileges: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscaleMySQLGrantCreate(d *schema.ResourceData, meta interface{}) error {
+	user := d.Get("user").(string)
+	host := d.Get("host").(string)
+	database := d.Get("database").(string)
+	errorPrefix := fmt.Sprintf("create MySQL grant (%s@%s on %s) resource -", user, host, database)
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	privileges := convSOStrings(d.Get("privileges").(*schema.Set).List())
+	query := fmt.Sprintf("GRANT %s ON %s.* TO ?@?", strings.Join(privileges, ", "), quoteMySQLGrantDatabase(database))
+	if _, err = db.ExecContext(ctx, query, user, host); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	id := fmt.Sprintf("%s@%s:%s", user, host, database)
+	d.SetId(id)
+
+	log.Printf("The id for the new MySQL grant has been set to %v", id)
+	return resourceGridscaleMySQLGrantRead(d, meta)
+}
+
+func resourceGridscaleMySQLGrantUpdate(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("update MySQL grant (%s) resource -", d.Id())
+	if d.HasChange("privileges") {
+		db, err := openMySQLConnection(d)
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+		oldPrivileges, newPrivileges := d.GetChange("privileges")
+		database := quoteMySQLGrantDatabase(d.Get("database").(string))
+		user := d.Get("user").(string)
+		host := d.Get("host").(string)
+		if revoked := convSOStrings(oldPrivileges.(*schema.Set).Difference(newPrivileges.(*schema.Set)).List()); len(revoked) > 0 {
+			query := fmt.Sprintf("REVOKE %s ON %s.* FROM ?@?", strings.Join(revoked, ", "), database)
+			if _, err = db.ExecContext(ctx, query, user, host); err != nil {
+				return fmt.Errorf("%s error: %v", errorPrefix, err)
+			}
+		}
+		if granted := convSOStrings(newPrivileges.(*schema.Set).Difference(oldPrivileges.(*schema.Set)).List()); len(granted) > 0 {
+			query := fmt.Sprintf("GRANT %s ON %s.* TO ?@?", strings.Join(granted, ", "), database)
+			if _, err = db.ExecContext(ctx, query, user, host); err != nil {
+				return fmt.Errorf("%s error: %v", errorPrefix, err)
+			}
+		}
+	}
+	return resourceGridscaleMySQLGrantRead(d, meta)
+}
+
+func resourceGridscaleMySQLGrantDelete(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("delete MySQL grant (%s) resource -", d.Id())
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	privileges := convSOStrings(d.Get("privileges").(*schema.Set).List())
+	query := fmt.Sprintf("REVOKE %s ON %s.* FROM ?@?", strings.Join(privileges, ", "), quoteMySQLGrantDatabase(d.Get("database").(string)))
+	if _, err = db.ExecContext(ctx, query, d.Get("user").(string), d.Get("host").(string)); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+// quoteMySQLGrantDatabase quotes a database name for the ON clause of GRANT and REVOKE.
+// "_" and "%" are escaped, as they are wildcards in this clause.
+func quoteMySQLGrantDatabase(database string) string {
+	return sqlu.QuoteMySQLIdentifier(sqlu.EscapeMySQLDatabasePattern(database))
+}
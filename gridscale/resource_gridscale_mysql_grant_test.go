@@ -0,0 +1,63 @@
+package gridscale
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscaleMySQLGrant_Basic(t *testing.T) {
+	serviceURL := testAccMySQLServiceURL(t)
+	name := fmt.Sprintf("app_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckGridscaleMySQLObjectDestroyCheck(serviceURL, "gridscale_mysql_user",
+			"SELECT COUNT(*) FROM mysql.user WHERE User = ? AND Host = ?", "user", "host"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleMySQLGrantConfig_basic(serviceURL, name, `"SELECT", "INSERT"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql_grant.foo", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						"gridscale_mysql_grant.foo", "privileges.*", "SELECT"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleMySQLGrantConfig_basic(serviceURL, name, `"SELECT", "UPDATE", "DELETE"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql_grant.foo", "privileges.#", "3"),
+					resource.TestCheckTypeSetElemAttr(
+						"gridscale_mysql_grant.foo", "privileges.*", "DELETE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleMySQLGrantConfig_basic(serviceURL *url.URL, name, privileges string) string {
+	service := testAccMySQLServiceBlock(serviceURL)
+	return fmt.Sprintf(`
+resource "gridscale_mysql_user" "foo" {%s
+	user = "%s"
+	password = "secret"
+}
+
+resource "gridscale_mysql_database" "foo" {%s
+	name = "%s"
+}
+
+resource "gridscale_mysql_grant" "foo" {%s
+	user = gridscale_mysql_user.foo.user
+	host = gridscale_mysql_user.foo.host
+	database = gridscale_mysql_database.foo.name
+	privileges = [%s]
+}
+`, service, name, service, name, service, privileges)
+}
@@ -2,10 +2,12 @@ package gridscale
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/gridscale/gsclient-go/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"testing"
 )
@@ -39,6 +41,109 @@ func TestAccResourceGridscaleMySQL_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscaleMySQL_TemplateChange(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("MySQL-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleMySQLConfig_template(name, "8.0", "standard", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_mysql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql.test", "release", "8.0"),
+				),
+			},
+			{
+				Config:      testAccCheckResourceGridscaleMySQLConfig_template(name, "5.7", "standard", false),
+				ExpectError: regexp.MustCompile("cannot be changed in-place"),
+			},
+			{
+				Config: testAccCheckResourceGridscaleMySQLConfig_template(name, "5.7", "standard", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_mysql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql.test", "release", "5.7"),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql.test", "template_change", "replace"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscaleMySQL_Backup(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("mysql-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleMySQLConfig_backup(name, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_mysql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql.test", "s3_backup.0.backup_bucket", name),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql.test", "s3_backup.0.backup_server_url", "https://gos3.io/"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleMySQLConfig_backup(name, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_mysql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql.test", "s3_backup.0.backup_retention", "3600"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscaleMySQL_RotateCredentials(t *testing.T) {
+	var object gsclient.PaaSService
+	var oldPassword string
+	name := fmt.Sprintf("mysql-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleMySQLConfig_rotateCredentials(name, "2021-01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_mysql.test", &object),
+					func(s *terraform.State) error {
+						oldPassword = s.RootModule().Resources["gridscale_mysql.test"].Primary.Attributes["password"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleMySQLConfig_rotateCredentials(name, "2021-04"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_mysql.test", "rotate_credentials", "2021-04"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["gridscale_mysql.test"].Primary.Attributes["password"] == oldPassword {
+							return fmt.Errorf("password has not been renewed")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscaleMySQLConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "gridscale_mysql" "test" {
@@ -61,3 +166,53 @@ resource "gridscale_mysql" "test" {
 }
 `)
 }
+
+func testAccCheckResourceGridscaleMySQLConfig_template(name, release, performanceClass string, allowReplace bool) string {
+	return fmt.Sprintf(`
+resource "gridscale_mysql" "test" {
+	name = "%s"
+	release = "%s"
+	performance_class = "%s"
+	allow_upgrade_by_replace = %t
+}
+`, name, release, performanceClass, allowReplace)
+}
+
+func testAccCheckResourceGridscaleMySQLConfig_backup(name string, retention int) string {
+	return fmt.Sprintf(`
+resource "gridscale_object_storage_accesskey" "test" {
+	timeouts {
+		create="10m"
+	}
+}
+
+resource "gridscale_object_storage_bucket" "test" {
+	access_key = gridscale_object_storage_accesskey.test.access_key
+	secret_key = gridscale_object_storage_accesskey.test.secret_key
+	bucket_name = "%s"
+}
+
+resource "gridscale_mysql" "test" {
+	name = "%s"
+	release = "8.0"
+	performance_class = "standard"
+	s3_backup {
+		backup_bucket = gridscale_object_storage_bucket.test.bucket_name
+		backup_retention = %d
+		backup_access_key = gridscale_object_storage_accesskey.test.access_key
+		backup_secret_key = gridscale_object_storage_accesskey.test.secret_key
+	}
+}
+`, name, name, retention)
+}
+
+func testAccCheckResourceGridscaleMySQLConfig_rotateCredentials(name, rotateCredentials string) string {
+	return fmt.Sprintf(`
+resource "gridscale_mysql" "test" {
+	name = "%s"
+	release = "8.0"
+	performance_class = "standard"
+	rotate_credentials = "%s"
+}
+`, name, rotateCredentials)
+}
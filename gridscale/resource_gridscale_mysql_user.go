@@ -0,0 +1,129 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGridscaleMySQLUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscaleMySQLUserCreate,
+		Read:   resourceGridscaleMySQLUserRead,
+		Update: resourceGridscaleMySQLUserUpdate,
+		Delete: resourceGridscaleMySQLUserDelete,
+		Schema: map[string]*schema.Schema{
+			"service": getMySQLConnectionSchema(),
+			"user": {
+				Type:         schema.TypeString,
+				Description:  "Name of the user.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"host": {
+				Type:         schema.TypeString,
+				Description:  "Host the user connects from.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "%",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "Password of the user.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleMySQLUserRead(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("read MySQL user (%s) resource -", d.Id())
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	var count int
+	err = db.QueryRowContext(context.Background(),
+		"SELECT COUNT(*) FROM mysql.user WHERE User = ? AND Host = ?", d.Get("user").(string), d.Get("host").(string),
+	).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	if count == 0 {
+		log.Printf("[WARN] MySQL user %s not found, removing it from the state", d.Id())
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceGridscaleMySQLUserCreate(d *schema.ResourceData, meta interface{}) error {
+	user := d.Get("user").(string)
+	host := d.Get("host").(string)
+	errorPrefix := fmt.Sprintf("create MySQL user (%s@%s) resource -", user, host)
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	if _, err = db.ExecContext(ctx, "CREATE USER ?@? IDENTIFIED BY ?", user, host, d.Get("password").(string)); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	id := fmt.Sprintf("%s@%s", user, host)
+	d.SetId(id)
+
+	log.Printf("The id for the new MySQL user has been set to %v", id)
+	return resourceGridscaleMySQLUserRead(d, meta)
+}
+
+func resourceGridscaleMySQLUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("update MySQL user (%s) resource -", d.Id())
+	if d.HasChange("password") {
+		db, err := openMySQLConnection(d)
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+		_, err = db.ExecContext(ctx, "ALTER USER ?@? IDENTIFIED BY ?", d.Get("user").(string), d.Get("host").(string), d.Get("password").(string))
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
+	return resourceGridscaleMySQLUserRead(d, meta)
+}
+
+func resourceGridscaleMySQLUserDelete(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("delete MySQL user (%s) resource -", d.Id())
+	db, err := openMySQLConnection(d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	if _, err = db.ExecContext(ctx, "DROP USER IF EXISTS ?@?", d.Get("user").(string), d.Get("host").(string)); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
@@ -11,12 +11,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
+	paasu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/paas-utils"
 
 	"log"
 )
 
 func resourceGridscalePaaS() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscalePaaSServiceCreate,
 		Read:   resourceGridscalePaaSServiceRead,
 		Delete: resourceGridscalePaaSServiceDelete,
@@ -24,6 +25,7 @@ func resourceGridscalePaaS() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizePaaSParametersDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -125,10 +127,26 @@ func resourceGridscalePaaS() *schema.Resource {
 				Description: "Current status of PaaS service",
 				Computed:    true,
 			},
+			"parameters": {
+				Type: schema.TypeMap,
+				Description: `Parameters of the PaaS service. Values are converted to the types defined in the parameters schema
+				of the service template (lists and dicts are JSON encoded). Changing an immutable parameter forces a new service.`,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"parameter"},
+			},
+			"parameters_computed": {
+				Type:        schema.TypeMap,
+				Description: "Effective parameters of the PaaS service, including the defaults of the service template.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"parameter": {
-				Type:        schema.TypeSet,
-				Description: "Parameter for PaaS service",
-				Optional:    true,
+				Type:          schema.TypeSet,
+				Description:   "Parameter for PaaS service",
+				Deprecated:    "Use parameters instead.",
+				Optional:      true,
+				ConflictsWith: []string{"parameters"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"param": {
@@ -189,6 +207,8 @@ func resourceGridscalePaaS() *schema.Resource {
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 	}
+	addPaaSCredentialRotation(resource, "username", "password", "kubeconfig")
+	return resource
 }
 
 func resourceGridscalePaaSServiceRead(d *schema.ResourceData, meta interface{}) error {
@@ -265,22 +285,53 @@ func resourceGridscalePaaSServiceRead(d *schema.ResourceData, meta interface{})
 	}
 
 	//Get parameters
-	parameters := make([]interface{}, 0)
+	effectiveParameters := make(map[string]interface{})
 	for k, value := range props.Parameters {
-		paramValType, err := getInterfaceType(value)
+		valueInString, err := paasu.FormatParameterValue(value)
 		if err != nil {
 			return fmt.Errorf("%s error: %v", errorPrefix, err)
 		}
-		valueInString, err := convInterfaceToString(paramValType, value)
-		param := map[string]interface{}{
-			"param": k,
-			"value": valueInString,
-			"type":  paramValType,
+		effectiveParameters[k] = valueInString
+	}
+	if err = d.Set("parameters_computed", effectiveParameters); err != nil {
+		return fmt.Errorf("%s error setting parameters_computed: %v", errorPrefix, err)
+	}
+	// Only configured parameters are set, so that the defaults of the template do not cause a diff
+	configuredParameters := d.Get("parameters").(map[string]interface{})
+	if len(configuredParameters) > 0 {
+		parameters := make(map[string]interface{})
+		for k, configuredValue := range configuredParameters {
+			value, ok := props.Parameters[k]
+			if !ok {
+				continue
+			}
+			if paasu.EqualParameterValues(configuredValue.(string), value) {
+				parameters[k] = configuredValue
+			} else {
+				parameters[k] = effectiveParameters[k]
+			}
+		}
+		if err = d.Set("parameters", parameters); err != nil {
+			return fmt.Errorf("%s error setting parameters: %v", errorPrefix, err)
+		}
+	} else {
+		parameters := make([]interface{}, 0)
+		for k, value := range props.Parameters {
+			paramValType, err := getInterfaceType(value)
+			if err != nil {
+				return fmt.Errorf("%s error: %v", errorPrefix, err)
+			}
+			valueInString, err := convInterfaceToString(paramValType, value)
+			param := map[string]interface{}{
+				"param": k,
+				"value": valueInString,
+				"type":  paramValType,
+			}
+			parameters = append(parameters, param)
+		}
+		if err = d.Set("parameter", parameters); err != nil {
+			return fmt.Errorf("%s error setting parameters: %v", errorPrefix, err)
 		}
-		parameters = append(parameters, param)
-	}
-	if err = d.Set("parameter", parameters); err != nil {
-		return fmt.Errorf("%s error setting parameters: %v", errorPrefix, err)
 	}
 
 	//Get resource limits
@@ -340,19 +391,11 @@ func resourceGridscalePaaSServiceCreate(d *schema.ResourceData, meta interface{}
 		requestBody.PaaSSecurityZoneUUID = secZoneUUIDInf.(string)
 	}
 
-	params := make(map[string]interface{}, 0)
-	for _, value := range d.Get("parameter").(*schema.Set).List() {
-		mapVal := value.(map[string]interface{})
-		var param string
-		var val interface{}
-		param = mapVal["param"].(string)
-		paramValType := mapVal["type"].(string)
-		typedVal, err := convStrToTypeInterface(paramValType, mapVal["value"].(string))
-		if err != nil {
-			return err
-		}
-		val = typedVal
-		params[param] = val
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	params, err := expandPaaSServiceParameters(ctx, client, d)
+	if err != nil {
+		return err
 	}
 	requestBody.Parameters = params
 
@@ -366,8 +409,6 @@ func resourceGridscalePaaSServiceCreate(d *schema.ResourceData, meta interface{}
 	}
 	requestBody.ResourceLimits = limits
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
-	defer cancel()
 	response, err := client.CreatePaaSService(ctx, requestBody)
 	if err != nil {
 		return err
@@ -399,19 +440,11 @@ func resourceGridscalePaaSServiceUpdate(d *schema.ResourceData, meta interface{}
 		requestBody.PaaSServiceTemplateUUID = d.Get("service_template_uuid").(string)
 	}
 
-	params := make(map[string]interface{}, 0)
-	for _, value := range d.Get("parameter").(*schema.Set).List() {
-		mapVal := value.(map[string]interface{})
-		var param string
-		var val interface{}
-		param = mapVal["param"].(string)
-		paramValType := mapVal["type"].(string)
-		typedVal, err := convStrToTypeInterface(paramValType, mapVal["value"].(string))
-		if err != nil {
-			return fmt.Errorf("%s error: %v", errorPrefix, err)
-		}
-		val = typedVal
-		params[param] = val
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	params, err := expandPaaSServiceParameters(ctx, client, d)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
 	requestBody.Parameters = params
 
@@ -425,12 +458,15 @@ func resourceGridscalePaaSServiceUpdate(d *schema.ResourceData, meta interface{}
 	}
 	requestBody.ResourceLimits = limits
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
-	defer cancel()
-	err := client.UpdatePaaSService(ctx, d.Id(), requestBody)
+	err = client.UpdatePaaSService(ctx, d.Id(), requestBody)
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
+	if d.HasChange("rotate_credentials") {
+		if err = renewPaaSCredentials(ctx, client, d.Id()); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
 	return resourceGridscalePaaSServiceRead(d, meta)
 }
 
@@ -3,6 +3,7 @@ package gridscale
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"github.com/gridscale/gsclient-go/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -49,6 +50,39 @@ func TestAccResourceGridscalePaaS_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscalePaaS_Parameters(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckResourceGridscalePaaSConfig_parameters(name, "mysql_max_conections", "2000"),
+				ExpectError: regexp.MustCompile("'mysql_max_conections' is not a valid parameter"),
+			},
+			{
+				Config:      testAccCheckResourceGridscalePaaSConfig_parameters(name, "mysql_max_connections", "many"),
+				ExpectError: regexp.MustCompile("Invalid 'mysql_max_connections' value"),
+			},
+			{
+				Config: testAccCheckResourceGridscalePaaSConfig_parameters(name, "mysql_max_connections", "2000"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_paas.foopaas", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_paas.foopaas", "parameters.mysql_max_connections", "2000"),
+					resource.TestCheckResourceAttr(
+						"gridscale_paas.foopaas", "parameters_computed.mysql_max_connections", "2000"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_paas.foopaas", "parameters_computed.mysql_default_time_zone"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscalePaaSExists(n string, object *gsclient.PaaSService) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -161,3 +195,15 @@ resource "gridscale_paas" "foopaas" {
 }
 `)
 }
+
+func testAccCheckResourceGridscalePaaSConfig_parameters(name, param, value string) string {
+	return fmt.Sprintf(`
+resource "gridscale_paas" "foopaas" {
+  name = "%s"
+  service_template_uuid = "8bcb216c-65ec-4c93-925d-1b8feaa5c2c5"
+  parameters = {
+    %s = "%s"
+  }
+}
+`, name, param, value)
+}
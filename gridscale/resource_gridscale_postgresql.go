@@ -18,13 +18,23 @@ import (
 
 const postgresTemplateFlavourName = "postgres"
 
+// postgreSQLParameters are the PaaS parameters of a PostgreSQL service
+// which are exposed as attributes with the same name.
+var postgreSQLParameters = []string{
+	"pgsql_max_connections",
+	"pgsql_shared_buffers",
+	"pgsql_work_mem",
+	"pgsql_timezone",
+	"pgsql_extensions",
+}
+
 const (
 	postgresReleaseValidationOpt = iota
 	postgresMaxCoreCountValidationOpt
 )
 
 func resourceGridscalePostgreSQL() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscalePostgreSQLCreate,
 		Read:   resourceGridscalePostgreSQLRead,
 		Delete: resourceGridscalePostgreSQLDelete,
@@ -63,6 +73,32 @@ func resourceGridscalePostgreSQL() *schema.Resource {
 				}
 				return nil
 			}),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				client := meta.(*gsclient.Client)
+				paasTemplates, err := client.GetPaaSTemplateList(ctx)
+				if err != nil {
+					return err
+				}
+				releaseVal := d.Get("release").(string)
+				perfClassVal := d.Get("performance_class").(string)
+				for _, template := range paasTemplates {
+					if template.Properties.Flavour == postgresTemplateFlavourName &&
+						template.Properties.Release == releaseVal && template.Properties.PerformanceClass == perfClassVal {
+						if err := validatePaaSParameterAttributes(d, template, postgreSQLParameters); err != nil {
+							return err
+						}
+						if err := validatePaaSAutoscaling(d, template); err != nil {
+							return err
+						}
+						if err := validatePaaSBackup(ctx, d, client, template); err != nil {
+							return err
+						}
+						return customizePaaSTemplateChangeDiff(d, paasTemplates, template)
+					}
+				}
+				// Invalid releases are reported by the release validation
+				return nil
+			},
 		),
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -74,7 +110,7 @@ func resourceGridscalePostgreSQL() *schema.Resource {
 			"release": {
 				Type: schema.TypeString,
 				Description: `The PostgreSQL release of this instance.\n
-				For convenience, please use gscloud https://github.com/gridscale/gscloud to get the list of available PostgreSQL service releases.`,
+				Use the gridscale_paas_templates data source (or gscloud https://github.com/gridscale/gscloud) to get the list of available PostgreSQL service releases.`,
 				Required:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
@@ -96,6 +132,41 @@ func resourceGridscalePostgreSQL() *schema.Resource {
 					return
 				},
 			},
+			"pgsql_max_connections": {
+				Type:         schema.TypeInt,
+				Description:  "Max Connections.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"pgsql_shared_buffers": {
+				Type:         schema.TypeString,
+				Description:  "Shared Buffers. Format: xMB (where x is an integer, MB stands for unit: kB, MB, GB).",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"pgsql_work_mem": {
+				Type:         schema.TypeString,
+				Description:  "Work Memory. Format: xMB (where x is an integer, MB stands for unit: kB, MB, GB).",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"pgsql_timezone": {
+				Type:         schema.TypeString,
+				Description:  "Server Timezone.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"pgsql_extensions": {
+				Type:        schema.TypeSet,
+				Description: "Enabled extensions.",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Description: "Username for PostgreSQL service. It is used to connect to the PostgreSQL instance.",
@@ -198,6 +269,11 @@ func resourceGridscalePostgreSQL() *schema.Resource {
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 	}
+	addPaaSAutoscalingSchema(resource.Schema)
+	addPaaSTemplateChangeSchema(resource.Schema)
+	addPaaSBackupSchema(resource.Schema)
+	addPaaSCredentialRotation(resource, "username", "password")
+	return resource
 }
 
 func resourceGridscalePostgreSQLRead(d *schema.ResourceData, meta interface{}) error {
@@ -276,6 +352,21 @@ func resourceGridscalePostgreSQLRead(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	//Get parameters
+	if err = setPaaSParameterAttributes(d, props, postgreSQLParameters); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	//Get autoscaling limits
+	if err = setPaaSAutoscaling(client, d, props); err != nil {
+		return fmt.Errorf("%s error setting autoscaling: %v", errorPrefix, err)
+	}
+
+	//Get backup settings
+	if err = setPaaSBackup(d, props); err != nil {
+		return fmt.Errorf("%s error setting s3_backup: %v", errorPrefix, err)
+	}
+
 	//Set labels
 	if err = d.Set("labels", props.Labels); err != nil {
 		return fmt.Errorf("%s error setting labels: %v", errorPrefix, err)
@@ -330,15 +421,14 @@ func resourceGridscalePostgreSQLCreate(d *schema.ResourceData, meta interface{})
 	if secZoneUUIDInf, ok := d.GetOk("security_zone_uuid"); ok && !isNetworkSet {
 		requestBody.PaaSSecurityZoneUUID = secZoneUUIDInf.(string)
 	}
-	if val, ok := d.GetOk("max_core_count"); ok {
-		limits := []gsclient.ResourceLimit{
-			{
-				Resource: "cores",
-				Limit:    val.(int),
-			},
-		}
+	if limits := expandPaaSAutoscalingLimits(d, true); len(limits) > 0 {
 		requestBody.ResourceLimits = limits
 	}
+	params := expandPaaSParameterAttributes(d, postgreSQLParameters)
+	expandPaaSBackupParameters(d, params, true)
+	if len(params) > 0 {
+		requestBody.Parameters = params
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
@@ -375,15 +465,14 @@ func resourceGridscalePostgreSQLUpdate(d *schema.ResourceData, meta interface{})
 		requestBody.PaaSServiceTemplateUUID = templateUUID
 	}
 
-	if val, ok := d.GetOk("max_core_count"); ok {
-		limits := []gsclient.ResourceLimit{
-			{
-				Resource: "cores",
-				Limit:    val.(int),
-			},
-		}
+	if limits := expandPaaSAutoscalingLimits(d, true); len(limits) > 0 {
 		requestBody.ResourceLimits = limits
 	}
+	params := expandPaaSParameterAttributes(d, postgreSQLParameters)
+	expandPaaSBackupParameters(d, params, false)
+	if len(params) > 0 {
+		requestBody.Parameters = params
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
@@ -391,6 +480,11 @@ func resourceGridscalePostgreSQLUpdate(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
+	if d.HasChange("rotate_credentials") {
+		if err = renewPaaSCredentials(ctx, client, d.Id()); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
 	return resourceGridscalePostgreSQLRead(d, meta)
 }
 
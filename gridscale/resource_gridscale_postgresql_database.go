@@ -0,0 +1,134 @@
+package gridscale
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+func resourceGridscalePostgreSQLDatabase() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscalePostgreSQLDatabaseCreate,
+		Read:   resourceGridscalePostgreSQLDatabaseRead,
+		Update: resourceGridscalePostgreSQLDatabaseUpdate,
+		Delete: resourceGridscalePostgreSQLDatabaseDelete,
+		Schema: map[string]*schema.Schema{
+			"service": getPostgreSQLConnectionSchema(),
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the database.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"owner": {
+				Type:         schema.TypeString,
+				Description:  "Role which owns the database. Default: the administrative user of the `service` block.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscalePostgreSQLDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("read PostgreSQL database (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, "")
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	var owner string
+	err = db.QueryRowContext(context.Background(),
+		"SELECT pg_get_userbyid(datdba) FROM pg_database WHERE datname = $1", d.Id(),
+	).Scan(&owner)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] PostgreSQL database %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	if err = d.Set("name", d.Id()); err != nil {
+		return fmt.Errorf("%s error setting name: %v", errorPrefix, err)
+	}
+	if err = d.Set("owner", owner); err != nil {
+		return fmt.Errorf("%s error setting owner: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscalePostgreSQLDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	errorPrefix := fmt.Sprintf("create PostgreSQL database (%s) resource -", name)
+	db, err := openPostgreSQLConnection(d, "")
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	query := fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(name))
+	if owner, ok := d.GetOk("owner"); ok {
+		query += fmt.Sprintf(" OWNER %s", pq.QuoteIdentifier(owner.(string)))
+	}
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	d.SetId(name)
+
+	log.Printf("The id for the new PostgreSQL database has been set to %v", name)
+	return resourceGridscalePostgreSQLDatabaseRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLDatabaseUpdate(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("update PostgreSQL database (%s) resource -", d.Id())
+	if d.HasChange("owner") {
+		db, err := openPostgreSQLConnection(d, "")
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+		query := fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(d.Id()), pq.QuoteIdentifier(d.Get("owner").(string)))
+		if _, err = db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
+	return resourceGridscalePostgreSQLDatabaseRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("delete PostgreSQL database (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, "")
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	if _, err = db.ExecContext(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s", pq.QuoteIdentifier(d.Id()))); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package gridscale
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscalePostgreSQLDatabase_Basic(t *testing.T) {
+	serviceURL := testAccPostgreSQLServiceURL(t)
+	name := fmt.Sprintf("db_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckGridscalePostgreSQLObjectDestroyCheck(serviceURL, "gridscale_postgresql_database",
+			"SELECT COUNT(*) FROM pg_database WHERE datname = $1", "name"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscalePostgreSQLDatabaseConfig_basic(serviceURL, name, "gridscale_postgresql_role.foo.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql_database.foo", "name", name),
+					resource.TestCheckResourceAttrPair(
+						"gridscale_postgresql_database.foo", "owner", "gridscale_postgresql_role.foo", "name"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscalePostgreSQLDatabaseConfig_basic(serviceURL, name, "gridscale_postgresql_role.bar.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"gridscale_postgresql_database.foo", "owner", "gridscale_postgresql_role.bar", "name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscalePostgreSQLDatabaseConfig_basic(serviceURL *url.URL, name, owner string) string {
+	service := testAccPostgreSQLServiceBlock(serviceURL)
+	return fmt.Sprintf(`
+resource "gridscale_postgresql_role" "foo" {%s
+	name = "%s_foo"
+	login = false
+}
+
+resource "gridscale_postgresql_role" "bar" {%s
+	name = "%s_bar"
+	login = false
+}
+
+resource "gridscale_postgresql_database" "foo" {%s
+	name = "%s"
+	owner = %s
+}
+`, service, name, service, name, service, name, owner)
+}
@@ -0,0 +1,324 @@
+package gridscale
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+	sqlu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/sql-utils"
+)
+
+// postgreSQLGrantTableKinds are the kinds of relations (tables, partitioned tables, views,
+// materialized views and foreign tables) which GRANT ... ON ALL TABLES IN SCHEMA applies to.
+const postgreSQLGrantTableKinds = "('r', 'p', 'v', 'm', 'f')"
+
+func resourceGridscalePostgreSQLGrant() *schema.Resource {
+	var privilegeDescriptions []string
+	for _, objectType := range sqlu.PostgreSQLObjectTypes {
+		privilegeDescriptions = append(privilegeDescriptions, fmt.Sprintf("%s: %s", objectType, strings.Join(sqlu.PostgreSQLPrivileges[objectType], ", ")))
+	}
+	return &schema.Resource{
+		Create: resourceGridscalePostgreSQLGrantCreate,
+		Read:   resourceGridscalePostgreSQLGrantRead,
+		Update: resourceGridscalePostgreSQLGrantUpdate,
+		Delete: resourceGridscalePostgreSQLGrantDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			objectType := d.Get("object_type").(string)
+			if _, ok := d.GetOk("schema"); ok && objectType == "database" {
+				return fmt.Errorf("schema cannot be set if object_type is database")
+			} else if !ok && objectType != "database" && d.NewValueKnown("schema") {
+				return fmt.Errorf("schema is required if object_type is %s", objectType)
+			}
+			if _, ok := d.GetOk("objects"); ok && objectType != "table" {
+				return fmt.Errorf("objects can only be set if object_type is table")
+			}
+			if !d.NewValueKnown("privileges") {
+				return nil
+			}
+			return sqlu.ValidatePostgreSQLPrivileges(objectType, convSOStrings(d.Get("privileges").(*schema.Set).List()))
+		},
+		Schema: map[string]*schema.Schema{
+			"service": getPostgreSQLConnectionSchema(),
+			"role": {
+				Type:         schema.TypeString,
+				Description:  "Role the privileges are granted to.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"database": {
+				Type:         schema.TypeString,
+				Description:  "Database the privileges are granted on, or which contains the schema or the tables.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"object_type": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("Type of the object the privileges are granted on. Valid values are: %s.", strings.Join(sqlu.PostgreSQLObjectTypes, ", ")),
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(sqlu.PostgreSQLObjectTypes, false),
+			},
+			"schema": {
+				Type:         schema.TypeString,
+				Description:  "Schema the privileges are granted on, or which contains the tables. Required if object_type is schema or table.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"objects": {
+				Type:        schema.TypeSet,
+				Description: "Tables the privileges are granted on. Default: all tables of the schema. Only valid if object_type is table.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"privileges": {
+				Type:        schema.TypeSet,
+				Description: fmt.Sprintf("Privileges which are granted. Valid values depend on object_type (%s).", strings.Join(privilegeDescriptions, "; ")),
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+// postgreSQLGrantTarget returns the object of the ON clause of GRANT and REVOKE.
+func postgreSQLGrantTarget(d *schema.ResourceData) string {
+	switch d.Get("object_type").(string) {
+	case "database":
+		return "DATABASE " + pq.QuoteIdentifier(d.Get("database").(string))
+	case "schema":
+		return "SCHEMA " + pq.QuoteIdentifier(d.Get("schema").(string))
+	}
+	schemaName := pq.QuoteIdentifier(d.Get("schema").(string))
+	objects := convSOStrings(d.Get("objects").(*schema.Set).List())
+	if len(objects) == 0 {
+		return "ALL TABLES IN SCHEMA " + schemaName
+	}
+	var tables []string
+	for _, object := range objects {
+		tables = append(tables, schemaName+"."+pq.QuoteIdentifier(object))
+	}
+	sort.Strings(tables)
+	return "TABLE " + strings.Join(tables, ", ")
+}
+
+// readPostgreSQLTablePrivileges returns the privileges which the role has on all tables of the grant.
+// ok is false if the schema contains none of the tables, so that the privileges cannot be determined.
+func readPostgreSQLTablePrivileges(ctx context.Context, db *sql.DB, d *schema.ResourceData) (privileges []string, ok bool, err error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT c.relname, COALESCE(a.privilege_type, '') FROM pg_class c "+
+			"JOIN pg_namespace n ON n.oid = c.relnamespace "+
+			"LEFT JOIN LATERAL aclexplode(c.relacl) a ON a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $2) "+
+			"WHERE n.nspname = $1 AND c.relkind IN "+postgreSQLGrantTableKinds,
+		d.Get("schema").(string), d.Get("role").(string),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+	tablePrivileges := make(map[string]map[string]bool)
+	for rows.Next() {
+		var table, privilege string
+		if err = rows.Scan(&table, &privilege); err != nil {
+			return nil, false, err
+		}
+		if tablePrivileges[table] == nil {
+			tablePrivileges[table] = make(map[string]bool)
+		}
+		if privilege != "" {
+			tablePrivileges[table][privilege] = true
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	tables := convSOStrings(d.Get("objects").(*schema.Set).List())
+	if len(tables) == 0 {
+		for table := range tablePrivileges {
+			tables = append(tables, table)
+		}
+	}
+	// Only the privileges which the role has on every table are granted
+	privilegeCounts := make(map[string]int)
+	for _, table := range tables {
+		for privilege := range tablePrivileges[table] {
+			privilegeCounts[privilege]++
+		}
+	}
+	for privilege, count := range privilegeCounts {
+		if count == len(tables) {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges, len(tables) > 0, nil
+}
+
+func resourceGridscalePostgreSQLGrantRead(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("read PostgreSQL grant (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, d.Get("database").(string))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	var privileges []string
+	var query, name string
+	switch d.Get("object_type").(string) {
+	case "database":
+		query = "SELECT a.privilege_type FROM pg_database o CROSS JOIN LATERAL aclexplode(o.datacl) a " +
+			"WHERE o.datname = $1 AND a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $2)"
+		name = d.Get("database").(string)
+	case "schema":
+		query = "SELECT a.privilege_type FROM pg_namespace o CROSS JOIN LATERAL aclexplode(o.nspacl) a " +
+			"WHERE o.nspname = $1 AND a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $2)"
+		name = d.Get("schema").(string)
+	default:
+		var ok bool
+		privileges, ok, err = readPostgreSQLTablePrivileges(ctx, db, d)
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		if !ok {
+			// Without tables, the privileges of the grant cannot be determined. They
+			// are kept, so that the grant is not created again on every apply.
+			log.Printf("[DEBUG] schema of PostgreSQL grant %s contains none of its tables", d.Id())
+			return nil
+		}
+	}
+	if query != "" {
+		rows, err := db.QueryContext(ctx, query, name, d.Get("role").(string))
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var privilege string
+			if err = rows.Scan(&privilege); err != nil {
+				return fmt.Errorf("%s error: %v", errorPrefix, err)
+			}
+			privileges = append(privileges, privilege)
+		}
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
+	if len(privileges) == 0 {
+		log.Printf("[WARN] PostgreSQL grant %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("privileges", privileges); err != nil {
+		return fmt.Errorf("%s error setting privileges: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscalePostgreSQLGrantCreate(d *schema.ResourceData, meta interface{}) error {
+	role := d.Get("role").(string)
+	database := d.Get("database").(string)
+	target := postgreSQLGrantTarget(d)
+	errorPrefix := fmt.Sprintf("create PostgreSQL grant (%s on %s in database %s) resource -", role, target, database)
+	db, err := openPostgreSQLConnection(d, database)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	privileges := convSOStrings(d.Get("privileges").(*schema.Set).List())
+	query := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(privileges, ", "), target, pq.QuoteIdentifier(role))
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	id := fmt.Sprintf("%s@%s", role, database)
+	if schemaName, ok := d.GetOk("schema"); ok {
+		id += "." + schemaName.(string)
+	}
+	if d.Get("object_type").(string) == "table" {
+		objects := convSOStrings(d.Get("objects").(*schema.Set).List())
+		if len(objects) == 0 {
+			objects = []string{"*"}
+		}
+		sort.Strings(objects)
+		id += ":" + strings.Join(objects, ",")
+	}
+	d.SetId(id)
+
+	log.Printf("The id for the new PostgreSQL grant has been set to %v", id)
+	return resourceGridscalePostgreSQLGrantRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLGrantUpdate(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("update PostgreSQL grant (%s) resource -", d.Id())
+	if d.HasChange("privileges") {
+		db, err := openPostgreSQLConnection(d, d.Get("database").(string))
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+		oldPrivileges, newPrivileges := d.GetChange("privileges")
+		target := postgreSQLGrantTarget(d)
+		role := pq.QuoteIdentifier(d.Get("role").(string))
+		if revoked := convSOStrings(oldPrivileges.(*schema.Set).Difference(newPrivileges.(*schema.Set)).List()); len(revoked) > 0 {
+			query := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(revoked, ", "), target, role)
+			if _, err = db.ExecContext(ctx, query); err != nil {
+				return fmt.Errorf("%s error: %v", errorPrefix, err)
+			}
+		}
+		if granted := convSOStrings(newPrivileges.(*schema.Set).Difference(oldPrivileges.(*schema.Set)).List()); len(granted) > 0 {
+			query := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(granted, ", "), target, role)
+			if _, err = db.ExecContext(ctx, query); err != nil {
+				return fmt.Errorf("%s error: %v", errorPrefix, err)
+			}
+		}
+	}
+	return resourceGridscalePostgreSQLGrantRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLGrantDelete(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("delete PostgreSQL grant (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, d.Get("database").(string))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	privileges := convSOStrings(d.Get("privileges").(*schema.Set).List())
+	query := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(privileges, ", "), postgreSQLGrantTarget(d), pq.QuoteIdentifier(d.Get("role").(string)))
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
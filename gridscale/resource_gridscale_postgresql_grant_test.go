@@ -0,0 +1,80 @@
+package gridscale
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscalePostgreSQLGrant_Basic(t *testing.T) {
+	serviceURL := testAccPostgreSQLServiceURL(t)
+	name := fmt.Sprintf("grant_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckGridscalePostgreSQLObjectDestroyCheck(serviceURL, "gridscale_postgresql_role",
+			"SELECT COUNT(*) FROM pg_roles WHERE rolname = $1", "name"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscalePostgreSQLGrantConfig_basic(serviceURL, name, `"USAGE"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql_grant.database", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr(
+						"gridscale_postgresql_grant.database", "privileges.*", "CONNECT"),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql_grant.schema", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr(
+						"gridscale_postgresql_grant.schema", "privileges.*", "USAGE"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscalePostgreSQLGrantConfig_basic(serviceURL, name, `"USAGE", "CREATE"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql_grant.schema", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						"gridscale_postgresql_grant.schema", "privileges.*", "CREATE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscalePostgreSQLGrantConfig_basic(serviceURL *url.URL, name, schemaPrivileges string) string {
+	service := testAccPostgreSQLServiceBlock(serviceURL)
+	return fmt.Sprintf(`
+resource "gridscale_postgresql_role" "foo" {%s
+	name = "%s"
+	login = false
+}
+
+resource "gridscale_postgresql_database" "foo" {%s
+	name = "%s"
+}
+
+resource "gridscale_postgresql_schema" "foo" {%s
+	database = gridscale_postgresql_database.foo.name
+	name = "%s"
+}
+
+resource "gridscale_postgresql_grant" "database" {%s
+	role = gridscale_postgresql_role.foo.name
+	database = gridscale_postgresql_database.foo.name
+	object_type = "database"
+	privileges = ["CONNECT"]
+}
+
+resource "gridscale_postgresql_grant" "schema" {%s
+	role = gridscale_postgresql_role.foo.name
+	database = gridscale_postgresql_database.foo.name
+	object_type = "schema"
+	schema = gridscale_postgresql_schema.foo.name
+	privileges = [%s]
+}
+`, service, name, service, name, service, name, service, service, schemaPrivileges)
+}
@@ -0,0 +1,189 @@
+package gridscale
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+func resourceGridscalePostgreSQLRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscalePostgreSQLRoleCreate,
+		Read:   resourceGridscalePostgreSQLRoleRead,
+		Update: resourceGridscalePostgreSQLRoleUpdate,
+		Delete: resourceGridscalePostgreSQLRoleDelete,
+		Schema: map[string]*schema.Schema{
+			"service": getPostgreSQLConnectionSchema(),
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the role.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "Password of the role.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"login": {
+				Type:        schema.TypeBool,
+				Description: "Allow the role to log in.",
+				Optional:    true,
+				Default:     true,
+			},
+			"create_database": {
+				Type:        schema.TypeBool,
+				Description: "Allow the role to create databases.",
+				Optional:    true,
+				Default:     false,
+			},
+			"create_role": {
+				Type:        schema.TypeBool,
+				Description: "Allow the role to create roles.",
+				Optional:    true,
+				Default:     false,
+			},
+			"connection_limit": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of concurrent connections of the role. -1 means no limit.",
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscalePostgreSQLRoleRead(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("read PostgreSQL role (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, "")
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	var login, createDatabase, createRole bool
+	var connectionLimit int
+	err = db.QueryRowContext(context.Background(),
+		"SELECT rolcanlogin, rolcreatedb, rolcreaterole, rolconnlimit FROM pg_roles WHERE rolname = $1", d.Id(),
+	).Scan(&login, &createDatabase, &createRole, &connectionLimit)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] PostgreSQL role %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	if err = d.Set("name", d.Id()); err != nil {
+		return fmt.Errorf("%s error setting name: %v", errorPrefix, err)
+	}
+	if err = d.Set("login", login); err != nil {
+		return fmt.Errorf("%s error setting login: %v", errorPrefix, err)
+	}
+	if err = d.Set("create_database", createDatabase); err != nil {
+		return fmt.Errorf("%s error setting create_database: %v", errorPrefix, err)
+	}
+	if err = d.Set("create_role", createRole); err != nil {
+		return fmt.Errorf("%s error setting create_role: %v", errorPrefix, err)
+	}
+	if err = d.Set("connection_limit", connectionLimit); err != nil {
+		return fmt.Errorf("%s error setting connection_limit: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscalePostgreSQLRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	errorPrefix := fmt.Sprintf("create PostgreSQL role (%s) resource -", name)
+	db, err := openPostgreSQLConnection(d, "")
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	query := fmt.Sprintf("CREATE ROLE %s WITH %s", pq.QuoteIdentifier(name), getPostgreSQLRoleOptions(d))
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	d.SetId(name)
+
+	log.Printf("The id for the new PostgreSQL role has been set to %v", name)
+	return resourceGridscalePostgreSQLRoleRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("update PostgreSQL role (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, "")
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	query := fmt.Sprintf("ALTER ROLE %s WITH %s", pq.QuoteIdentifier(d.Id()), getPostgreSQLRoleOptions(d))
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return resourceGridscalePostgreSQLRoleRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("delete PostgreSQL role (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, "")
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	if _, err = db.ExecContext(ctx, fmt.Sprintf("DROP ROLE IF EXISTS %s", pq.QuoteIdentifier(d.Id()))); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+// getPostgreSQLRoleOptions returns the options of CREATE ROLE and ALTER ROLE.
+// A removed password is set to NULL, so that the role cannot log in with the old one.
+func getPostgreSQLRoleOptions(d *schema.ResourceData) string {
+	options := []string{
+		"NOLOGIN",
+		"NOCREATEDB",
+		"NOCREATEROLE",
+		fmt.Sprintf("CONNECTION LIMIT %d", d.Get("connection_limit").(int)),
+		"PASSWORD NULL",
+	}
+	if d.Get("login").(bool) {
+		options[0] = "LOGIN"
+	}
+	if d.Get("create_database").(bool) {
+		options[1] = "CREATEDB"
+	}
+	if d.Get("create_role").(bool) {
+		options[2] = "CREATEROLE"
+	}
+	if password := d.Get("password").(string); password != "" {
+		options[4] = fmt.Sprintf("PASSWORD %s", pq.QuoteLiteral(password))
+	}
+	return strings.Join(options, " ")
+}
@@ -0,0 +1,140 @@
+package gridscale
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+func resourceGridscalePostgreSQLSchema() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscalePostgreSQLSchemaCreate,
+		Read:   resourceGridscalePostgreSQLSchemaRead,
+		Update: resourceGridscalePostgreSQLSchemaUpdate,
+		Delete: resourceGridscalePostgreSQLSchemaDelete,
+		Schema: map[string]*schema.Schema{
+			"service": getPostgreSQLConnectionSchema(),
+			"database": {
+				Type:         schema.TypeString,
+				Description:  "Database the schema is created in.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the schema.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"owner": {
+				Type:         schema.TypeString,
+				Description:  "Role which owns the schema. Default: the administrative user of the `service` block.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscalePostgreSQLSchemaRead(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("read PostgreSQL schema (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, d.Get("database").(string))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	var owner string
+	err = db.QueryRowContext(context.Background(),
+		"SELECT pg_get_userbyid(nspowner) FROM pg_namespace WHERE nspname = $1", d.Get("name").(string),
+	).Scan(&owner)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] PostgreSQL schema %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	if err = d.Set("owner", owner); err != nil {
+		return fmt.Errorf("%s error setting owner: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscalePostgreSQLSchemaCreate(d *schema.ResourceData, meta interface{}) error {
+	database := d.Get("database").(string)
+	name := d.Get("name").(string)
+	errorPrefix := fmt.Sprintf("create PostgreSQL schema (%s) in database %s resource -", name, database)
+	db, err := openPostgreSQLConnection(d, database)
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	query := fmt.Sprintf("CREATE SCHEMA %s", pq.QuoteIdentifier(name))
+	if owner, ok := d.GetOk("owner"); ok {
+		query += fmt.Sprintf(" AUTHORIZATION %s", pq.QuoteIdentifier(owner.(string)))
+	}
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	id := fmt.Sprintf("%s.%s", database, name)
+	d.SetId(id)
+
+	log.Printf("The id for the new PostgreSQL schema has been set to %v", id)
+	return resourceGridscalePostgreSQLSchemaRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLSchemaUpdate(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("update PostgreSQL schema (%s) resource -", d.Id())
+	if d.HasChange("owner") {
+		db, err := openPostgreSQLConnection(d, d.Get("database").(string))
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+		query := fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(d.Get("name").(string)), pq.QuoteIdentifier(d.Get("owner").(string)))
+		if _, err = db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
+	return resourceGridscalePostgreSQLSchemaRead(d, meta)
+}
+
+func resourceGridscalePostgreSQLSchemaDelete(d *schema.ResourceData, meta interface{}) error {
+	errorPrefix := fmt.Sprintf("delete PostgreSQL schema (%s) resource -", d.Id())
+	db, err := openPostgreSQLConnection(d, d.Get("database").(string))
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	if _, err = db.ExecContext(ctx, fmt.Sprintf("DROP SCHEMA IF EXISTS %s", pq.QuoteIdentifier(d.Get("name").(string)))); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package gridscale
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscalePostgreSQLSchema_Basic(t *testing.T) {
+	serviceURL := testAccPostgreSQLServiceURL(t)
+	name := fmt.Sprintf("schema_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: testAccCheckGridscalePostgreSQLObjectDestroyCheck(serviceURL, "gridscale_postgresql_database",
+			"SELECT COUNT(*) FROM pg_database WHERE datname = $1", "name"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscalePostgreSQLSchemaConfig_basic(serviceURL, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql_schema.foo", "name", name),
+					resource.TestCheckResourceAttrPair(
+						"gridscale_postgresql_schema.foo", "database", "gridscale_postgresql_database.foo", "name"),
+					resource.TestCheckResourceAttrPair(
+						"gridscale_postgresql_schema.foo", "owner", "gridscale_postgresql_role.foo", "name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscalePostgreSQLSchemaConfig_basic(serviceURL *url.URL, name string) string {
+	service := testAccPostgreSQLServiceBlock(serviceURL)
+	return fmt.Sprintf(`
+resource "gridscale_postgresql_role" "foo" {%s
+	name = "%s"
+	login = false
+}
+
+resource "gridscale_postgresql_database" "foo" {%s
+	name = "%s"
+}
+
+resource "gridscale_postgresql_schema" "foo" {%s
+	database = gridscale_postgresql_database.foo.name
+	name = "%s"
+	owner = gridscale_postgresql_role.foo.name
+}
+`, service, name, service, name, service, name)
+}
@@ -2,10 +2,12 @@ package gridscale
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/gridscale/gsclient-go/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"testing"
 )
@@ -39,6 +41,178 @@ func TestAccResourceGridscalePostgres_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscalePostgres_TemplateChange(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("postgres-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscalePostgresConfig_template(name, "14", "standard"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_postgresql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "release", "14"),
+				),
+			},
+			{
+				Config:      testAccCheckResourceGridscalePostgresConfig_template(name, "13", "standard"),
+				ExpectError: regexp.MustCompile("cannot be changed in-place"),
+			},
+			{
+				Config: testAccCheckResourceGridscalePostgresConfig_template(name, "14", "high"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_postgresql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "performance_class", "high"),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "template_change", "performance_class_update"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscalePostgres_Autoscaling(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("postgres-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckResourceGridscalePostgresConfig_autoscaling(name, 1000),
+				ExpectError: regexp.MustCompile("Invalid 'autoscaling.0.cores_max' value"),
+			},
+			{
+				Config: testAccCheckResourceGridscalePostgresConfig_autoscaling(name, 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_postgresql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "autoscaling.0.cores_max", "4"),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "max_core_count", "4"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_postgresql.test", "autoscaling.0.cores_min"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscalePostgres_Parameters(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("postgres-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscalePostgresConfig_parameters(name, 200, "8MB"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_postgresql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "pgsql_max_connections", "200"),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "pgsql_work_mem", "8MB"),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "pgsql_timezone", "Europe/Berlin"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_postgresql.test", "pgsql_shared_buffers"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscalePostgresConfig_parameters(name, 300, "16MB"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_postgresql.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "pgsql_max_connections", "300"),
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "pgsql_work_mem", "16MB"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscalePostgres_Backup(t *testing.T) {
+	name := fmt.Sprintf("postgres-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckResourceGridscalePostgresConfig_backup(name, "https://example.com/"),
+				ExpectError: regexp.MustCompile("only https://gos3.io/ is supported"),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscalePostgres_RestoreUnknownSource(t *testing.T) {
+	name := fmt.Sprintf("postgres-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				// Rejected at plan time, either because the release does not support restores
+				// or because the source service does not exist.
+				Config:      testAccCheckResourceGridscalePostgresConfig_restore(name, "00000000-0000-4000-8000-000000000000"),
+				ExpectError: regexp.MustCompile(`'restore\.0\.\w+' is not supported|source service \S+ of the restore does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscalePostgres_RotateCredentials(t *testing.T) {
+	var object gsclient.PaaSService
+	var oldPassword string
+	name := fmt.Sprintf("postgres-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscalePostgresConfig_rotateCredentials(name, "2021-01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_postgresql.test", &object),
+					func(s *terraform.State) error {
+						oldPassword = s.RootModule().Resources["gridscale_postgresql.test"].Primary.Attributes["password"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscalePostgresConfig_rotateCredentials(name, "2021-04"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_postgresql.test", "rotate_credentials", "2021-04"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["gridscale_postgresql.test"].Primary.Attributes["password"] == oldPassword {
+							return fmt.Errorf("password has not been renewed")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscalePostgresConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "gridscale_postgresql" "test" {
@@ -60,3 +234,109 @@ resource "gridscale_postgresql" "test" {
 }
 `)
 }
+
+func testAccCheckResourceGridscalePostgresConfig_template(name, release, performanceClass string) string {
+	return fmt.Sprintf(`
+resource "gridscale_postgresql" "test" {
+	name = "%s"
+	release = "%s"
+	performance_class = "%s"
+}
+`, name, release, performanceClass)
+}
+
+func testAccCheckResourceGridscalePostgresConfig_autoscaling(name string, coresMax int) string {
+	return fmt.Sprintf(`
+resource "gridscale_postgresql" "test" {
+	name = "%s"
+	release = "13"
+	performance_class = "standard"
+	autoscaling {
+		cores_max = %d
+	}
+}
+`, name, coresMax)
+}
+
+func testAccCheckResourceGridscalePostgresConfig_parameters(name string, maxConnections int, workMem string) string {
+	return fmt.Sprintf(`
+resource "gridscale_postgresql" "test" {
+	name = "%s"
+	release = "13"
+	performance_class = "standard"
+	pgsql_max_connections = %d
+	pgsql_work_mem = "%s"
+	pgsql_timezone = "Europe/Berlin"
+}
+`, name, maxConnections, workMem)
+}
+
+func testAccCheckResourceGridscalePostgresConfig_backup(name, serverURL string) string {
+	return fmt.Sprintf(`
+resource "gridscale_object_storage_accesskey" "test" {
+	timeouts {
+		create="10m"
+	}
+}
+
+resource "gridscale_object_storage_bucket" "test" {
+	access_key = gridscale_object_storage_accesskey.test.access_key
+	secret_key = gridscale_object_storage_accesskey.test.secret_key
+	bucket_name = "%s"
+}
+
+resource "gridscale_postgresql" "test" {
+	name = "%s"
+	release = "13"
+	performance_class = "standard"
+	s3_backup {
+		backup_bucket = gridscale_object_storage_bucket.test.bucket_name
+		backup_access_key = gridscale_object_storage_accesskey.test.access_key
+		backup_secret_key = gridscale_object_storage_accesskey.test.secret_key
+		backup_server_url = "%s"
+	}
+}
+`, name, name, serverURL)
+}
+
+func testAccCheckResourceGridscalePostgresConfig_restore(name, sourceServiceUUID string) string {
+	return fmt.Sprintf(`
+resource "gridscale_object_storage_accesskey" "test" {
+	timeouts {
+		create="10m"
+	}
+}
+
+resource "gridscale_object_storage_bucket" "test" {
+	access_key = gridscale_object_storage_accesskey.test.access_key
+	secret_key = gridscale_object_storage_accesskey.test.secret_key
+	bucket_name = "%s"
+}
+
+resource "gridscale_postgresql" "test" {
+	name = "%s"
+	release = "13"
+	performance_class = "standard"
+	s3_backup {
+		backup_bucket = gridscale_object_storage_bucket.test.bucket_name
+		backup_access_key = gridscale_object_storage_accesskey.test.access_key
+		backup_secret_key = gridscale_object_storage_accesskey.test.secret_key
+	}
+	restore {
+		source_service_uuid = "%s"
+		backup_name = "nightly"
+	}
+}
+`, name, name, sourceServiceUUID)
+}
+
+func testAccCheckResourceGridscalePostgresConfig_rotateCredentials(name, rotateCredentials string) string {
+	return fmt.Sprintf(`
+resource "gridscale_postgresql" "test" {
+	name = "%s"
+	release = "13"
+	performance_class = "standard"
+	rotate_credentials = "%s"
+}
+`, name, rotateCredentials)
+}
@@ -39,6 +39,35 @@ func TestAccResourceGridscaleRedisCache_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscaleRedisCache_Parameters(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("redis_cache-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleRedisCacheConfig_parameters(name, "allkeys-lru"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_redis_cache.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_redis_cache.test", "redis_maxmemory_policy", "allkeys-lru"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleRedisCacheConfig_parameters(name, "volatile-ttl"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_redis_cache.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_redis_cache.test", "redis_maxmemory_policy", "volatile-ttl"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscaleRedisCacheConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "gridscale_redis_cache" "test" {
@@ -59,3 +88,14 @@ resource "gridscale_redis_cache" "test" {
 }
 `)
 }
+
+func testAccCheckResourceGridscaleRedisCacheConfig_parameters(name, maxmemoryPolicy string) string {
+	return fmt.Sprintf(`
+resource "gridscale_redis_cache" "test" {
+	name = "%s"
+	release = "5.0"
+	performance_class = "standard"
+	redis_maxmemory_policy = "%s"
+}
+`, name, maxmemoryPolicy)
+}
@@ -18,8 +18,17 @@ import (
 
 const redisStoreTemplateFlavourName = "redis-store"
 
+// redisStoreParameters are the PaaS parameters of a Redis store service
+// which are exposed as attributes with the same name.
+var redisStoreParameters = []string{
+	"redis_maxmemory_policy",
+	"redis_persistence",
+	"redis_tls",
+	"redis_require_password",
+}
+
 func resourceGridscaleRedisStore() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscaleRedisStoreCreate,
 		Read:   resourceGridscaleRedisStoreRead,
 		Delete: resourceGridscaleRedisStoreDelete,
@@ -36,6 +45,7 @@ func resourceGridscaleRedisStore() *schema.Resource {
 
 			releaseVal := d.Get("release").(string)
 			perfClassVal := d.Get("performance_class").(string)
+			var chosenTemplate gsclient.PaaSTemplate
 			var isReleasePerfClassValid bool
 			releaseWPerfClasess := make(map[string][]string)
 			for _, template := range paasTemplates {
@@ -44,6 +54,7 @@ func resourceGridscaleRedisStore() *schema.Resource {
 					releaseWPerfClasess[template.Properties.Release] = append(perfClasses, template.Properties.PerformanceClass)
 					if template.Properties.Release == releaseVal && template.Properties.PerformanceClass == perfClassVal {
 						isReleasePerfClassValid = true
+						chosenTemplate = template
 					}
 				}
 			}
@@ -54,7 +65,10 @@ func resourceGridscaleRedisStore() *schema.Resource {
 				}
 				return errors.New(errMess)
 			}
-			return nil
+			if err := validatePaaSAutoscaling(d, chosenTemplate); err != nil {
+				return err
+			}
+			return validatePaaSParameterAttributes(d, chosenTemplate, redisStoreParameters)
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -66,7 +80,7 @@ func resourceGridscaleRedisStore() *schema.Resource {
 			"release": {
 				Type: schema.TypeString,
 				Description: `The RedisStore release of this instance.\n
-				For convenience, please use gscloud https://github.com/gridscale/gscloud to get the list of available Redis store service releases.`,
+				Use the gridscale_paas_templates data source (or gscloud https://github.com/gridscale/gscloud) to get the list of available Redis store service releases.`,
 				Required:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
@@ -76,6 +90,32 @@ func resourceGridscaleRedisStore() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
+			"redis_maxmemory_policy": {
+				Type:         schema.TypeString,
+				Description:  "Eviction policy when the memory limit is reached.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"redis_persistence": {
+				Type:         schema.TypeString,
+				Description:  "Persistence mode (RDB snapshots and/or AOF log).",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"redis_tls": {
+				Type:        schema.TypeBool,
+				Description: "Accept TLS connections only.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"redis_require_password": {
+				Type:        schema.TypeBool,
+				Description: "Require clients to authenticate with `username` and `password`.",
+				Optional:    true,
+				Computed:    true,
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Description: "Username for Redis store service. It is used to connect to the Redis store instance.",
@@ -166,6 +206,9 @@ func resourceGridscaleRedisStore() *schema.Resource {
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 	}
+	addPaaSAutoscalingSchema(resource.Schema)
+	addPaaSCredentialRotation(resource, "username", "password")
+	return resource
 }
 
 func resourceGridscaleRedisStoreRead(d *schema.ResourceData, meta interface{}) error {
@@ -235,6 +278,16 @@ func resourceGridscaleRedisStoreRead(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("%s error setting listen ports: %v", errorPrefix, err)
 	}
 
+	//Get parameters
+	if err = setPaaSParameterAttributes(d, props, redisStoreParameters); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	//Get autoscaling limits
+	if err = setPaaSAutoscaling(client, d, props); err != nil {
+		return fmt.Errorf("%s error setting autoscaling: %v", errorPrefix, err)
+	}
+
 	//Set labels
 	if err = d.Set("labels", props.Labels); err != nil {
 		return fmt.Errorf("%s error setting labels: %v", errorPrefix, err)
@@ -289,6 +342,13 @@ func resourceGridscaleRedisStoreCreate(d *schema.ResourceData, meta interface{})
 	if secZoneUUIDInf, ok := d.GetOk("security_zone_uuid"); ok && !isNetworkSet {
 		requestBody.PaaSSecurityZoneUUID = secZoneUUIDInf.(string)
 	}
+	if limits := expandPaaSAutoscalingLimits(d, false); len(limits) > 0 {
+		requestBody.ResourceLimits = limits
+	}
+	if params := expandPaaSParameterAttributes(d, redisStoreParameters); len(params) > 0 {
+		requestBody.Parameters = params
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
 	response, err := client.CreatePaaSService(ctx, requestBody)
@@ -324,12 +384,24 @@ func resourceGridscaleRedisStoreUpdate(d *schema.ResourceData, meta interface{})
 		requestBody.PaaSServiceTemplateUUID = templateUUID
 	}
 
+	if limits := expandPaaSAutoscalingLimits(d, false); len(limits) > 0 {
+		requestBody.ResourceLimits = limits
+	}
+	if params := expandPaaSParameterAttributes(d, redisStoreParameters); len(params) > 0 {
+		requestBody.Parameters = params
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 	err := client.UpdatePaaSService(ctx, d.Id(), requestBody)
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
+	if d.HasChange("rotate_credentials") {
+		if err = renewPaaSCredentials(ctx, client, d.Id()); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
 	return resourceGridscaleRedisStoreRead(d, meta)
 }
 
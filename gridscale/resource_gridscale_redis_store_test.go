@@ -39,6 +39,37 @@ func TestAccResourceGridscaleRedisStore_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscaleRedisStore_Parameters(t *testing.T) {
+	var object gsclient.PaaSService
+	name := fmt.Sprintf("redis_store-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscalePaaSDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleRedisStoreConfig_parameters(name, "allkeys-lru"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_redis_store.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_redis_store.test", "redis_maxmemory_policy", "allkeys-lru"),
+					resource.TestCheckResourceAttr(
+						"gridscale_redis_store.test", "redis_persistence", "aof"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleRedisStoreConfig_parameters(name, "volatile-ttl"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscalePaaSExists("gridscale_redis_store.test", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_redis_store.test", "redis_maxmemory_policy", "volatile-ttl"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscaleRedisStoreConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "gridscale_redis_store" "test" {
@@ -59,3 +90,15 @@ resource "gridscale_redis_store" "test" {
 }
 `)
 }
+
+func testAccCheckResourceGridscaleRedisStoreConfig_parameters(name, maxmemoryPolicy string) string {
+	return fmt.Sprintf(`
+resource "gridscale_redis_store" "test" {
+	name = "%s"
+	release = "5.0"
+	performance_class = "standard"
+	redis_maxmemory_policy = "%s"
+	redis_persistence = "aof"
+}
+`, name, maxmemoryPolicy)
+}
@@ -29,6 +29,31 @@ func resourceGridscaleServer() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			for idx, netIntf := range d.Get("network").([]interface{}) {
+				network := netIntf.(map[string]interface{})
+				if err := validateDefaultInboundRulesConfig(
+					network["default_inbound_rules"].(string),
+					network["custom_default_rules_v4_in"].([]interface{}),
+					network["custom_default_rules_v6_in"].([]interface{}),
+				); err != nil {
+					return fmt.Errorf("network.%d: %v", idx, err)
+				}
+				// Validate the expansion of services and lists of CIDRs
+				if !d.NewValueKnown(fmt.Sprintf("network.%d", idx)) {
+					continue
+				}
+				for _, ruleType := range []string{"rules_v4_in", "rules_v4_out", "rules_v6_in", "rules_v6_out", "custom_default_rules_v4_in", "custom_default_rules_v6_in"} {
+					if _, err := fwu.ConvInterfaceSliceToFirewallRules(network[ruleType].([]interface{})); err != nil {
+						return fmt.Errorf("network.%d.%s: %v", idx, ruleType, err)
+					}
+				}
+			}
+			if err := customizeServerNetworkDefaultRulesDiff(d); err != nil {
+				return err
+			}
+			return validateServerLocalStorages(ctx, d, meta.(*gsclient.Client))
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -134,6 +159,8 @@ func resourceGridscaleServer() *schema.Resource {
 			"network": {
 				Type:     schema.TypeList,
 				Optional: true,
+				// Computed, so that the default inbound rules can be set in the plan (see customizeServerNetworkDefaultRulesDiff)
+				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"object_uuid": {
@@ -167,26 +194,66 @@ func resourceGridscaleServer() *schema.Resource {
 							Type:     schema.TypeList,
 							Optional: true,
 							Elem: &schema.Resource{
-								Schema: getFirewallRuleCommonSchema(),
+								Schema: getFirewallRuleSchemaWithReferences(),
 							},
 						},
 						"rules_v4_out": {
 							Type:     schema.TypeList,
 							Optional: true,
 							Elem: &schema.Resource{
-								Schema: getFirewallRuleCommonSchema(),
+								Schema: getFirewallRuleSchemaWithReferences(),
 							},
 						},
 						"rules_v6_in": {
 							Type:     schema.TypeList,
 							Optional: true,
 							Elem: &schema.Resource{
-								Schema: getFirewallRuleCommonSchema(),
+								Schema: getFirewallRuleSchemaWithReferences(),
 							},
 						},
 						"rules_v6_out": {
 							Type:     schema.TypeList,
 							Optional: true,
+							Elem: &schema.Resource{
+								Schema: getFirewallRuleSchemaWithReferences(),
+							},
+						},
+						"default_inbound_rules": {
+							Type: schema.TypeString,
+							Description: `Defines which default rules are added after the custom inbound rules (rules_v4_in, rules_v6_in).
+"gridscale" (default) adds the gridscale default rules (DHCP, high ports, drop all other traffic),
+"none" does not add any rules, "custom" adds the rules of custom_default_rules_v4_in and custom_default_rules_v6_in.`,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(fwu.DefaultInboundRulesModes, false),
+						},
+						"custom_default_rules_v4_in": {
+							Type:        schema.TypeList,
+							Description: `Default inbound rules (ipv4) added after the custom inbound rules, if default_inbound_rules is "custom".`,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: getFirewallDefaultRuleSchema(),
+							},
+						},
+						"custom_default_rules_v6_in": {
+							Type:        schema.TypeList,
+							Description: `Default inbound rules (ipv6) added after the custom inbound rules, if default_inbound_rules is "custom".`,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: getFirewallDefaultRuleSchema(),
+							},
+						},
+						"default_rules_v4_in": {
+							Type:        schema.TypeList,
+							Description: "Default inbound rules (ipv4) which are enforced after the custom inbound rules.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: getFirewallRuleCommonSchema(),
+							},
+						},
+						"default_rules_v6_in": {
+							Type:        schema.TypeList,
+							Description: "Default inbound rules (ipv6) which are enforced after the custom inbound rules.",
+							Computed:    true,
 							Elem: &schema.Resource{
 								Schema: getFirewallRuleCommonSchema(),
 							},
@@ -391,6 +458,48 @@ then it will proceed onto rule 2. Packets that do not match any rules are blocke
 	return schemaWithPointers
 }
 
+// getFirewallRuleSchemaWithReferences returns schema for declared firewall rules.
+// In addition to the common schema, a rule can reference a service of the service catalog
+// instead of protocol and dst_port, and lists of CIDRs (e.g. of an address group)
+// instead of src_cidr and dst_cidr. Such a rule is expanded to one rule per combination of CIDRs.
+func getFirewallRuleSchemaWithReferences() map[string]*schema.Schema {
+	ruleSchema := getFirewallRuleCommonSchema()
+	ruleSchema["protocol"].Required = false
+	ruleSchema["protocol"].Optional = true
+	ruleSchema["protocol"].Description = "Either 'udp' or 'tcp'. Required if service is not set."
+	ruleSchema["service"] = &schema.Schema{
+		Type: schema.TypeString,
+		Description: `Name of a well-known service (e.g. "https") which defines protocol and dst_port of the rule.
+Cannot be combined with protocol and dst_port.`,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(fwu.FirewallServiceNames(), false),
+	}
+	ruleSchema["src_cidrs"] = &schema.Schema{
+		Type: schema.TypeList,
+		Description: `List of IPv4/6 addresses or IP networks in CIDR format (e.g. the cidrs of a gridscale_firewall_address_group).
+The rule is expanded to one rule per CIDR with consecutive orders. Cannot be combined with src_cidr.`,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	ruleSchema["dst_cidrs"] = &schema.Schema{
+		Type: schema.TypeList,
+		Description: `List of IPv4/6 addresses or IP networks in CIDR format (e.g. the cidrs of a gridscale_firewall_address_group).
+The rule is expanded to one rule per CIDR with consecutive orders. Cannot be combined with dst_cidr.`,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	return ruleSchema
+}
+
+// getFirewallDefaultRuleSchema returns schema for custom default firewall rules.
+// Default rules do not have an order, they are ordered by their position
+// after the custom firewall rules.
+func getFirewallDefaultRuleSchema() map[string]*schema.Schema {
+	defaultRuleSchema := getFirewallRuleSchemaWithReferences()
+	delete(defaultRuleSchema, "order")
+	return defaultRuleSchema
+}
+
 func resourceGridscaleServerRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*gsclient.Client)
 	errorPrefix := fmt.Sprintf("read server (%s) resource -", d.Id())
@@ -482,16 +591,16 @@ func resourceGridscaleServerRead(d *schema.ResourceData, meta interface{}) error
 	}
 
 	//Get networks
-	netWODefaultRules := server.Properties.Relations.Networks
+	serverNetRels := server.Properties.Relations.Networks
 	// Sort the network list by their ordering
-	sort.Slice(netWODefaultRules, func(i, j int) bool { return netWODefaultRules[i].Ordering < netWODefaultRules[j].Ordering })
-	for i := 0; i < len(netWODefaultRules); i++ { // Remove all default rules, we don't want to display them
-		netWODefaultRules[i].
-			Firewall.RulesV4In = fwu.RemoveDefaultFirewallInboundRules(netWODefaultRules[i].Firewall.RulesV4In)
-		netWODefaultRules[i].
-			Firewall.RulesV6In = fwu.RemoveDefaultFirewallInboundRules(netWODefaultRules[i].Firewall.RulesV6In)
-	}
-	networks, err := readServerNetworkRels(context.Background(), client, d.Id(), netWODefaultRules)
+	sort.Slice(serverNetRels, func(i, j int) bool { return serverNetRels[i].Ordering < serverNetRels[j].Ordering })
+	// Default inbound rules are not known by the API, get them from the current configuration
+	netConfigs := make(map[string]map[string]interface{})
+	for _, netIntf := range d.Get("network").([]interface{}) {
+		network := netIntf.(map[string]interface{})
+		netConfigs[network["object_uuid"].(string)] = network
+	}
+	networks, err := readServerNetworkRels(context.Background(), client, d.Id(), serverNetRels, netConfigs)
 	if err != nil {
 		return fmt.Errorf("%s error reading server-network relations: %v", errorPrefix, err)
 	}
@@ -526,8 +635,11 @@ func resourceGridscaleServerRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-// readServerNetworkRels extract relationships between server and networks
-func readServerNetworkRels(ctx context.Context, client *gsclient.Client, serverUUID string, serverNetRels []gsclient.ServerNetworkRelationProperties) ([]interface{}, error) {
+// readServerNetworkRels extract relationships between server and networks.
+// netConfigs (keyed by network UUID) holds the configured default inbound rules of
+// the networks, default inbound rules are split from the custom rules accordingly.
+// If netConfigs is nil (e.g. in data sources), the gridscale default inbound rules are assumed.
+func readServerNetworkRels(ctx context.Context, client *gsclient.Client, serverUUID string, serverNetRels []gsclient.ServerNetworkRelationProperties, netConfigs map[string]map[string]interface{}) ([]interface{}, error) {
 	networks := make([]interface{}, 0)
 	for _, rel := range serverNetRels {
 		// Get DHCP IP information (if applicable)
@@ -560,39 +672,46 @@ func readServerNetworkRels(ctx context.Context, client *gsclient.Client, serverU
 			"ip":                     dhcpIP,
 			"auto_assigned_ip":       autoAssignedDHCPIP,
 		}
-		//Init all types of firewall rule
-		v4InRuleProps := make([]interface{}, 0)
-		v4OutRuleProps := make([]interface{}, 0)
-		v6InRuleProps := make([]interface{}, 0)
-		v6OutRuleProps := make([]interface{}, 0)
-
-		//Add rules of type rules_v4_in
-		for _, props := range rel.Firewall.RulesV4In {
-			v4InRuleProp := flattenFirewallRuleProperties(props)
-			v4InRuleProps = append(v4InRuleProps, v4InRuleProp)
+		// Split default inbound rules from the custom inbound rules
+		var mode string
+		var customDefaultRulesV4In, customDefaultRulesV6In []interface{}
+		netConfig, hasNetConfig := netConfigs[rel.ObjectUUID]
+		if hasNetConfig {
+			mode = netConfig["default_inbound_rules"].(string)
+			customDefaultRulesV4In = netConfig["custom_default_rules_v4_in"].([]interface{})
+			customDefaultRulesV6In = netConfig["custom_default_rules_v6_in"].([]interface{})
+			network["default_inbound_rules"] = mode
+			network["custom_default_rules_v4_in"] = customDefaultRulesV4In
+			network["custom_default_rules_v6_in"] = customDefaultRulesV6In
 		}
-		network["rules_v4_in"] = v4InRuleProps
-
-		//Add rules of type rules_v4_out
-		for _, props := range rel.Firewall.RulesV4Out {
-			v4OutRuleProp := flattenFirewallRuleProperties(props)
-			v4OutRuleProps = append(v4OutRuleProps, v4OutRuleProp)
+		expectedDefaultRulesV4In, err := expandDefaultFirewallInboundRules(mode, customDefaultRulesV4In, false)
+		if err != nil {
+			return nil, err
 		}
-		network["rules_v4_out"] = v4OutRuleProps
-
-		//Add rules of type rules_v6_in
-		for _, props := range rel.Firewall.RulesV6In {
-			v6InRuleProp := flattenFirewallRuleProperties(props)
-			v6InRuleProps = append(v6InRuleProps, v6InRuleProp)
+		expectedDefaultRulesV6In, err := expandDefaultFirewallInboundRules(mode, customDefaultRulesV6In, true)
+		if err != nil {
+			return nil, err
 		}
-		network["rules_v6_in"] = v6InRuleProps
-
-		//Add rules of type rules_v6_out
-		for _, props := range rel.Firewall.RulesV6Out {
-			v6OutRuleProp := flattenFirewallRuleProperties(props)
-			v6OutRuleProps = append(v6OutRuleProps, v6OutRuleProp)
+		var defaultRulesV4In, defaultRulesV6In []gsclient.FirewallRuleProperties
+		rel.Firewall.RulesV4In, defaultRulesV4In = fwu.SplitDefaultFirewallInboundRules(rel.Firewall.RulesV4In, expectedDefaultRulesV4In)
+		rel.Firewall.RulesV6In, defaultRulesV6In = fwu.SplitDefaultFirewallInboundRules(rel.Firewall.RulesV6In, expectedDefaultRulesV6In)
+		network["default_rules_v4_in"] = convFirewallRuleSliceToInterfaceSlice(defaultRulesV4In)
+		network["default_rules_v6_in"] = convFirewallRuleSliceToInterfaceSlice(defaultRulesV6In)
+
+		//Add all types of firewall rule. Declared services and lists of CIDRs
+		//are kept if they still match the rules
+		for ruleType, rules := range map[string][]gsclient.FirewallRuleProperties{
+			"rules_v4_in":  rel.Firewall.RulesV4In,
+			"rules_v4_out": rel.Firewall.RulesV4Out,
+			"rules_v6_in":  rel.Firewall.RulesV6In,
+			"rules_v6_out": rel.Firewall.RulesV6Out,
+		} {
+			var declaredRules []interface{}
+			if hasNetConfig {
+				declaredRules, _ = netConfig[ruleType].([]interface{})
+			}
+			network[ruleType] = flattenFirewallRulesWithConfig(rules, declaredRules)
 		}
-		network["rules_v6_out"] = v6OutRuleProps
 
 		networks = append(networks, network)
 	}
@@ -839,3 +958,48 @@ func resourceGridscaleServerUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 	return resourceGridscaleServerRead(d, meta)
 }
+
+// customizeServerNetworkDefaultRulesDiff shows the default inbound rules (default_rules_v4_in, default_rules_v6_in)
+// which will be enforced in the plan, like gridscale_firewall does. Nested computed attributes cannot be
+// set on their own, so the whole network list is set to the configured networks.
+func customizeServerNetworkDefaultRulesDiff(d *schema.ResourceDiff) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	rawNetworks := rawConfig.GetAttr("network")
+	if !rawNetworks.IsKnown() {
+		return nil
+	}
+	// All networks are removed from the configuration
+	if rawNetworks.IsNull() || rawNetworks.LengthInt() == 0 {
+		if len(d.Get("network").([]interface{})) == 0 {
+			return nil
+		}
+		return d.SetNew("network", []interface{}{})
+	}
+	networks := d.Get("network").([]interface{})
+	for idx := range networks {
+		if !d.NewValueKnown(fmt.Sprintf("network.%d", idx)) {
+			return nil
+		}
+	}
+	for idx, netIntf := range networks {
+		network := netIntf.(map[string]interface{})
+		mode := network["default_inbound_rules"].(string)
+		for ruleType, forIPv6 := range map[string]bool{"rules_v4_in": false, "rules_v6_in": true} {
+			rules, err := fwu.ConvInterfaceSliceToFirewallRules(network[ruleType].([]interface{}))
+			if err != nil {
+				return fmt.Errorf("network.%d.%s: %v", idx, ruleType, err)
+			}
+			customDefaultRuleType := fmt.Sprintf("custom_default_%s", ruleType)
+			defaultRules, err := expandDefaultFirewallInboundRules(mode, network[customDefaultRuleType].([]interface{}), forIPv6)
+			if err != nil {
+				return fmt.Errorf("network.%d.%s: %v", idx, customDefaultRuleType, err)
+			}
+			rulesWithDefault := fwu.AddDefaultFirewallInboundRules(rules, defaultRules)
+			network[fmt.Sprintf("default_%s", ruleType)] = convFirewallRuleSliceToInterfaceSlice(rulesWithDefault[len(rules):])
+		}
+	}
+	return d.SetNew("network", networks)
+}
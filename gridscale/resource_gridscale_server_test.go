@@ -53,6 +53,45 @@ func TestAccResourceGridscaleServer_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceGridscaleServer_DefaultInboundRules(t *testing.T) {
+	var object gsclient.Server
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGridscaleServerDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleServerConfig_default_inbound_rules(name, "gridscale"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleServerExists("gridscale_server.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "network.0.default_inbound_rules", "gridscale"),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "network.0.default_rules_v4_in.#", "5"),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "network.0.default_rules_v6_in.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleServerConfig_default_inbound_rules(name, "custom"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleServerExists("gridscale_server.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "network.0.default_inbound_rules", "custom"),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "network.0.default_rules_v4_in.#", "1"),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "network.0.default_rules_v4_in.0.order", "1"),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "network.0.default_rules_v4_in.0.dst_port", "1:65535"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscaleServerExists(n string, object *gsclient.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -199,3 +238,37 @@ resource "gridscale_server" "foo" {
 }
 `)
 }
+
+func testAccCheckResourceGridscaleServerConfig_default_inbound_rules(name, mode string) string {
+	customDefaultRules := ""
+	if mode == "custom" {
+		customDefaultRules = `
+		custom_default_rules_v4_in {
+				protocol = "tcp"
+				action = "drop"
+				dst_port = "1:65535"
+				comment = "drop all"
+		}`
+	}
+	return fmt.Sprintf(`
+resource "gridscale_network" "foo" {
+  name   = "net-%s"
+}
+resource "gridscale_server" "foo" {
+  name   = "%s"
+  cores = 1
+  memory = 1
+  network {
+		object_uuid = gridscale_network.foo.id
+		default_inbound_rules = "%s"
+		rules_v4_in {
+				order = 0
+				protocol = "tcp"
+				action = "accept"
+				dst_port = "22"
+				comment = "ssh"
+		}%s
+  	}
+}
+`, name, name, mode, customDefaultRules)
+}
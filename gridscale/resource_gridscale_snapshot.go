@@ -6,8 +6,12 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
 
@@ -130,8 +134,19 @@ the product_no of the license (see the /prices endpoint for more details)`,
 							Required: true,
 						},
 						"status": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the export request. It is \"success\" once the exported object exists in the object storage.",
+						},
+						"object_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Size of the exported object in bytes.",
+						},
+						"object_etag": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ETag of the exported object.",
 						},
 					},
 				},
@@ -158,6 +173,12 @@ the product_no of the license (see the /prices endpoint for more details)`,
 					},
 				},
 			},
+			"continue_on_error": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, a failed rollback or export does not fail the apply, the error is recorded in the status of the rollback/export request instead.",
+			},
 			"labels": {
 				Type:        schema.TypeSet,
 				Description: "List of labels.",
@@ -246,76 +267,20 @@ func resourceGridscaleSnapshotCreate(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 	errorPrefix := fmt.Sprintf("rollback storage (%s) snapshot (%s) -", storageUUID, response.ObjectUUID)
+	d.SetId(response.ObjectUUID)
+	log.Printf("The id for snapshot %s has been set to %v", requestBody.Name, response.ObjectUUID)
 
-	//Start rolling back if there are initially requests to rollback
-	if attr, ok := d.GetOk("rollback"); ok {
-		requests := make([]interface{}, 0)
-		for _, requestProps := range attr.(*schema.Set).List() {
-			rollbackReq := requestProps.(map[string]interface{})
-			//Rollback
-			log.Printf("Start rolling back storage %s with snapshot %s", storageUUID, response.ObjectUUID)
-			err = client.RollbackStorage(
-				ctx,
-				storageUUID,
-				response.ObjectUUID,
-				gsclient.StorageRollbackRequest{
-					Rollback: true,
-				},
-			)
-			//Set status
-			if err != nil {
-				rollbackReq["status"] = err.Error()
-			} else {
-				rollbackReq["status"] = "success"
-				log.Printf("Rolling back storage %s with snapshot %s SUCCESSFULLY", storageUUID, response.ObjectUUID)
-			}
-			//Set time of rollback request
-			rollbackReq["rollback_time"] = time.Now().Format(timeLayout)
-			requests = append(requests, rollbackReq)
-		}
-		//Apply value back to schema
-		if err = d.Set("rollback", requests); err != nil {
-			return fmt.Errorf("%s error setting rollback: %v", errorPrefix, err)
-		}
+	//Start rolling back if there are initially requests to rollback.
+	//A failed rollback does not fail the creation, otherwise the new snapshot would be tainted
+	//and replaced by the next apply. The failed request is not stored in the state,
+	//so that it is run again (as an update) by the next apply.
+	if err = runStorageSnapshotRollbackRequests(ctx, d, client, storageUUID, response.ObjectUUID); err != nil {
+		log.Printf("[WARN] %s error: %v", errorPrefix, err)
 	}
 	//Start exporting the snapshot to object storage if object storage data is set
-	if attr, ok := d.GetOk("object_storage_export"); ok {
-		requests := make([]interface{}, 0)
-		for _, requestProps := range attr.(*schema.Set).List() {
-			exportReqData := requestProps.(map[string]interface{})
-			objStorageHost := exportReqData["host"].(string)
-			objStorageHostURL, err := url.Parse(objStorageHost)
-			if err != nil {
-				return err
-			}
-			exportReqBody := gsclient.StorageSnapshotExportToS3Request{
-				S3auth: gsclient.S3auth{
-					Host:      objStorageHostURL.Host,
-					AccessKey: exportReqData["access_key"].(string),
-					SecretKey: exportReqData["secret_key"].(string),
-				},
-				S3data: gsclient.S3data{
-					Host:     objStorageHost,
-					Bucket:   exportReqData["bucket"].(string),
-					Filename: exportReqData["object"].(string),
-					Private:  exportReqData["private"].(bool),
-				},
-			}
-			err = client.ExportStorageSnapshotToS3(ctx, storageUUID, response.ObjectUUID, exportReqBody)
-			if err != nil {
-				exportReqData["status"] = err.Error()
-			} else {
-				exportReqData["status"] = "success"
-			}
-			requests = append(requests, exportReqData)
-		}
-		//Apply value back to schema
-		if err = d.Set("object_storage_export", requests); err != nil {
-			return fmt.Errorf("%s error setting export: %v", errorPrefix, err)
-		}
+	if err = runStorageSnapshotExportRequests(ctx, d, client, storageUUID, response.ObjectUUID); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
-	d.SetId(response.ObjectUUID)
-	log.Printf("The id for snapshot %s has been set to %v", requestBody.Name, response.ObjectUUID)
 	return resourceGridscaleSnapshotRead(d, meta)
 }
 
@@ -337,76 +302,12 @@ func resourceGridscaleSnapshotUpdate(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
 	//Start rolling back if there are new requests to rollback
-	if attr, ok := d.GetOk("rollback"); ok {
-		requests := make([]interface{}, 0)
-		for _, requestProps := range attr.(*schema.Set).List() {
-			rollbackReq := requestProps.(map[string]interface{})
-			//If `time` field of a request is set, it is the old request
-			if rollbackReq["rollback_time"] == "" {
-				//Rollback
-				log.Printf("Start rolling back storage %s with snapshot %s", storageUUID, d.Id())
-				err = client.RollbackStorage(
-					ctx,
-					storageUUID,
-					d.Id(),
-					gsclient.StorageRollbackRequest{
-						Rollback: true,
-					},
-				)
-				//Set status
-				if err != nil {
-					rollbackReq["status"] = err.Error()
-				} else {
-					rollbackReq["status"] = "success"
-					log.Printf("Rolling back storage %s with snapshot %s SUCCESSFULLY", storageUUID, d.Id())
-				}
-				//Set time of rollback request
-				rollbackReq["rollback_time"] = time.Now().Format(timeLayout)
-			}
-			requests = append(requests, rollbackReq)
-		}
-		//Apply value back to schema
-		if err = d.Set("rollback", requests); err != nil {
-			return fmt.Errorf("%s error setting rollback: %v", errorPrefix, err)
-		}
+	if err = runStorageSnapshotRollbackRequests(ctx, d, client, storageUUID, d.Id()); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
 	//Start exporting the snapshot to object storage if object storage data is set
-	if attr, ok := d.GetOk("object_storage_export"); ok {
-		requests := make([]interface{}, 0)
-		for _, requestProps := range attr.(*schema.Set).List() {
-			exportReqData := requestProps.(map[string]interface{})
-			if exportReqData["status"] == "" {
-				objStorageHost := exportReqData["host"].(string)
-				objStorageHostURL, err := url.Parse(objStorageHost)
-				if err != nil {
-					return err
-				}
-				exportReqBody := gsclient.StorageSnapshotExportToS3Request{
-					S3auth: gsclient.S3auth{
-						Host:      objStorageHostURL.Host,
-						AccessKey: exportReqData["access_key"].(string),
-						SecretKey: exportReqData["secret_key"].(string),
-					},
-					S3data: gsclient.S3data{
-						Host:     objStorageHost,
-						Bucket:   exportReqData["bucket"].(string),
-						Filename: exportReqData["object"].(string),
-						Private:  exportReqData["private"].(bool),
-					},
-				}
-				err = client.ExportStorageSnapshotToS3(ctx, storageUUID, d.Id(), exportReqBody)
-				if err != nil {
-					exportReqData["status"] = err.Error()
-				} else {
-					exportReqData["status"] = "success"
-				}
-			}
-			requests = append(requests, exportReqData)
-		}
-		//Apply value back to schema
-		if err = d.Set("object_storage_export", requests); err != nil {
-			return fmt.Errorf("%s error setting export: %v", errorPrefix, err)
-		}
+	if err = runStorageSnapshotExportRequests(ctx, d, client, storageUUID, d.Id()); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
 	return resourceGridscaleSnapshotRead(d, meta)
 }
@@ -427,3 +328,148 @@ func resourceGridscaleSnapshotDelete(d *schema.ResourceData, meta interface{}) e
 	}
 	return nil
 }
+
+// runStorageSnapshotRollbackRequests runs all new requests (requests without rollback_time) to rollback
+// a storage to a snapshot. If a rollback fails, an error is returned unless continue_on_error is true.
+// In that case the error is recorded in the status of the request. Otherwise the failed request
+// (and all requests which have not run yet) is removed from the state, so that it is run again by the next apply.
+func runStorageSnapshotRollbackRequests(ctx context.Context, d *schema.ResourceData, client *gsclient.Client, storageUUID, snapshotUUID string) error {
+	attr, ok := d.GetOk("rollback")
+	if !ok {
+		return nil
+	}
+	continueOnError := d.Get("continue_on_error").(bool)
+	requests := make([]interface{}, 0)
+	var rollbackErr error
+	for _, requestProps := range attr.(*schema.Set).List() {
+		rollbackReq := requestProps.(map[string]interface{})
+		//If `time` field of a request is set, it is the old request
+		if rollbackReq["rollback_time"] == "" {
+			//Do not run any other request after a failed rollback, they are run by the next apply
+			if rollbackErr != nil {
+				continue
+			}
+			log.Printf("Start rolling back storage %s with snapshot %s", storageUUID, snapshotUUID)
+			err := rollbackStorageSynchronously(ctx, client, storageUUID, snapshotUUID)
+			//Set status
+			if err != nil {
+				if !continueOnError {
+					rollbackErr = fmt.Errorf("rollback request (%s) failed: %v", rollbackReq["id"], err)
+					continue
+				}
+				log.Printf("[WARN] Rolling back storage %s with snapshot %s failed: %v", storageUUID, snapshotUUID, err)
+				rollbackReq["status"] = err.Error()
+			} else {
+				rollbackReq["status"] = "success"
+				log.Printf("Rolling back storage %s with snapshot %s SUCCESSFULLY", storageUUID, snapshotUUID)
+			}
+			//Set time of rollback request
+			rollbackReq["rollback_time"] = time.Now().Format(timeLayout)
+		}
+		requests = append(requests, rollbackReq)
+	}
+	//Apply value back to schema
+	if err := d.Set("rollback", requests); err != nil {
+		return err
+	}
+	return rollbackErr
+}
+
+// rollbackStorageSynchronously rolls back a storage to a snapshot. All servers which the storage
+// is attached to are shut down before the rollback, and they are started again
+// after the storage is active again.
+func rollbackStorageSynchronously(ctx context.Context, client *gsclient.Client, storageUUID, snapshotUUID string) error {
+	return runActionRequireStorageServersOff(ctx, client, storageUUID, func(ctx context.Context) error {
+		return client.RollbackStorage(ctx, storageUUID, snapshotUUID, gsclient.StorageRollbackRequest{
+			Rollback: true,
+		})
+	})
+}
+
+// runStorageSnapshotExportRequests runs all new requests (requests without status) to export a snapshot
+// to an object storage, and waits until the exported objects exist. If an export fails, an error is returned
+// unless continue_on_error is true. In that case the error is recorded in the status of the request.
+func runStorageSnapshotExportRequests(ctx context.Context, d *schema.ResourceData, client *gsclient.Client, storageUUID, snapshotUUID string) error {
+	attr, ok := d.GetOk("object_storage_export")
+	if !ok {
+		return nil
+	}
+	continueOnError := d.Get("continue_on_error").(bool)
+	requests := make([]interface{}, 0)
+	for _, requestProps := range attr.(*schema.Set).List() {
+		exportReqData := requestProps.(map[string]interface{})
+		//If `status` field of a request is set, it is the old request
+		if exportReqData["status"] == "" {
+			object, err := exportStorageSnapshotToObjectStorage(ctx, client, storageUUID, snapshotUUID, exportReqData)
+			if err != nil {
+				if !continueOnError {
+					return fmt.Errorf("export to %s/%s failed: %v", exportReqData["bucket"], exportReqData["object"], err)
+				}
+				log.Printf("[WARN] Exporting snapshot %s to %s/%s failed: %v", snapshotUUID, exportReqData["bucket"], exportReqData["object"], err)
+				exportReqData["status"] = err.Error()
+			} else {
+				exportReqData["status"] = "success"
+				exportReqData["object_size"] = int(aws.Int64Value(object.ContentLength))
+				exportReqData["object_etag"] = strings.Trim(aws.StringValue(object.ETag), "\"")
+			}
+		}
+		requests = append(requests, exportReqData)
+	}
+	//Apply value back to schema
+	return d.Set("object_storage_export", requests)
+}
+
+// exportStorageSnapshotToObjectStorage exports a snapshot to an object storage
+// and waits until the exported object exists.
+func exportStorageSnapshotToObjectStorage(ctx context.Context, client *gsclient.Client, storageUUID, snapshotUUID string, exportReqData map[string]interface{}) (*s3.HeadObjectOutput, error) {
+	objStorageHost := exportReqData["host"].(string)
+	objStorageHostURL, err := url.Parse(objStorageHost)
+	if err != nil {
+		return nil, err
+	}
+	exportReqBody := gsclient.StorageSnapshotExportToS3Request{
+		S3auth: gsclient.S3auth{
+			Host:      objStorageHostURL.Host,
+			AccessKey: exportReqData["access_key"].(string),
+			SecretKey: exportReqData["secret_key"].(string),
+		},
+		S3data: gsclient.S3data{
+			Host:     objStorageHost,
+			Bucket:   exportReqData["bucket"].(string),
+			Filename: exportReqData["object"].(string),
+			Private:  exportReqData["private"].(bool),
+		},
+	}
+	log.Printf("Start exporting snapshot %s of storage %s to %s/%s", snapshotUUID, storageUUID, exportReqBody.S3data.Bucket, exportReqBody.S3data.Filename)
+	if err = client.ExportStorageSnapshotToS3(ctx, storageUUID, snapshotUUID, exportReqBody); err != nil {
+		return nil, err
+	}
+	s3Client := initS3Client(&gridscaleS3Provider{
+		AccessKey: exportReqBody.S3auth.AccessKey,
+		SecretKey: exportReqBody.S3auth.SecretKey,
+	}, objStorageHost)
+	return waitForObjectStorageObject(ctx, s3Client, exportReqBody.S3data.Bucket, exportReqBody.S3data.Filename, client.DelayInterval())
+}
+
+// waitForObjectStorageObject waits until an object exists in a bucket of an object storage.
+func waitForObjectStorageObject(ctx context.Context, s3Client *s3.S3, bucket, key string, delay time.Duration) (*s3.HeadObjectOutput, error) {
+	for {
+		object, err := s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err == nil {
+			return object, nil
+		}
+		// The object does not exist yet
+		if reqErr, ok := err.(awserr.RequestFailure); !ok || reqErr.StatusCode() != http.StatusNotFound {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Waiting for object %s/%s to exist", bucket, key)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timeout waiting for object %s/%s to exist: %v", bucket, key, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
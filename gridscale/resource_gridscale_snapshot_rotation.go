@@ -0,0 +1,294 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+// snapshotRotationLabelPrefix is the prefix of the label which marks
+// the snapshots managed by a snapshot rotation
+const snapshotRotationLabelPrefix = "snapshot-rotation:"
+
+func resourceGridscaleSnapshotRotation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscaleSnapshotRotationCreate,
+		Read:   resourceGridscaleSnapshotRotationRead,
+		Update: resourceGridscaleSnapshotRotationUpdate,
+		Delete: resourceGridscaleSnapshotRotationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGridscaleSnapshotRotationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"storage_uuid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "UUID of the storage used to create snapshots",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the snapshot rotation. It is used as prefix of the names of the snapshots, and to identify the snapshots managed by the rotation.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"trigger": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "A new snapshot is created every time the trigger changes (e.g. a version string).",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"keep": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of managed snapshots to keep. The oldest snapshots are deleted first.",
+			},
+			"max_age": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := time.ParseDuration(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%v is not a valid duration (e.g. \"720h\"): %v", v.(string), err))
+					}
+					return
+				},
+				Description: "The maximum age of managed snapshots (e.g. \"720h\"). Older snapshots are deleted, the latest snapshot is always kept.",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Description: "List of labels added to the snapshots.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"delete_snapshots_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, all managed snapshots are deleted when the snapshot rotation is destroyed.",
+			},
+			"latest_snapshot_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the latest managed snapshot.",
+			},
+			"snapshot_uuids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "UUIDs of all managed snapshots, the latest snapshot first.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleSnapshotRotationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// ID format: <storage_uuid>/<name>
+	idParts := strings.SplitN(d.Id(), "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("invalid snapshot rotation ID (%s), expected format: <storage_uuid>/<name>", d.Id())
+	}
+	if err := d.Set("storage_uuid", idParts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("name", idParts[1]); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGridscaleSnapshotRotationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	storageUUID := d.Get("storage_uuid").(string)
+	errorPrefix := fmt.Sprintf("read snapshot rotation (%s) resource -", d.Id())
+
+	snapshots, err := getRotationSnapshots(context.Background(), client, storageUUID, d.Get("name").(string))
+	if err != nil {
+		if requestError, ok := err.(gsclient.RequestError); ok {
+			if requestError.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	snapshotUUIDs := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotUUIDs = append(snapshotUUIDs, snapshot.Properties.ObjectUUID)
+	}
+	var latestSnapshotUUID string
+	if len(snapshotUUIDs) > 0 {
+		latestSnapshotUUID = snapshotUUIDs[0]
+	}
+	if err = d.Set("latest_snapshot_uuid", latestSnapshotUUID); err != nil {
+		return fmt.Errorf("%s error setting latest_snapshot_uuid: %v", errorPrefix, err)
+	}
+	if err = d.Set("snapshot_uuids", snapshotUUIDs); err != nil {
+		return fmt.Errorf("%s error setting snapshot_uuids: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscaleSnapshotRotationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	storageUUID := d.Get("storage_uuid").(string)
+	name := d.Get("name").(string)
+	errorPrefix := fmt.Sprintf("create snapshot rotation (%s) of storage (%s) resource -", name, storageUUID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	if err := createRotationSnapshot(ctx, client, d); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+
+	id := fmt.Sprintf("%s/%s", storageUUID, name)
+	d.SetId(id)
+	log.Printf("The id for the new snapshot rotation has been set to %v", id)
+
+	if err := pruneRotationSnapshots(ctx, client, d); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return resourceGridscaleSnapshotRotationRead(d, meta)
+}
+
+func resourceGridscaleSnapshotRotationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("update snapshot rotation (%s) resource -", d.Id())
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	if d.HasChange("trigger") {
+		if err := createRotationSnapshot(ctx, client, d); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
+	if err := pruneRotationSnapshots(ctx, client, d); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return resourceGridscaleSnapshotRotationRead(d, meta)
+}
+
+func resourceGridscaleSnapshotRotationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	storageUUID := d.Get("storage_uuid").(string)
+	errorPrefix := fmt.Sprintf("delete snapshot rotation (%s) resource -", d.Id())
+
+	if !d.Get("delete_snapshots_on_destroy").(bool) {
+		log.Printf("[INFO] snapshot rotation (%s) is removed, the managed snapshots are kept", d.Id())
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	snapshots, err := getRotationSnapshots(ctx, client, storageUUID, d.Get("name").(string))
+	if errHandler.SuppressHTTPErrorCodes(err, http.StatusNotFound) != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	for _, snapshot := range snapshots {
+		err = errHandler.SuppressHTTPErrorCodes(
+			client.DeleteStorageSnapshot(ctx, storageUUID, snapshot.Properties.ObjectUUID),
+			http.StatusNotFound,
+		)
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
+	return nil
+}
+
+// getRotationSnapshotLabel returns the label which marks the snapshots of a snapshot rotation
+func getRotationSnapshotLabel(name string) string {
+	return snapshotRotationLabelPrefix + name
+}
+
+// getRotationSnapshots returns the snapshots of a storage managed by a snapshot rotation,
+// the latest snapshot first.
+func getRotationSnapshots(ctx context.Context, client *gsclient.Client, storageUUID, name string) ([]gsclient.StorageSnapshot, error) {
+	snapshots, err := client.GetStorageSnapshotList(ctx, storageUUID)
+	if err != nil {
+		return nil, err
+	}
+	label := getRotationSnapshotLabel(name)
+	managedSnapshots := make([]gsclient.StorageSnapshot, 0)
+	for _, snapshot := range snapshots {
+		for _, l := range snapshot.Properties.Labels {
+			if l == label {
+				managedSnapshots = append(managedSnapshots, snapshot)
+				break
+			}
+		}
+	}
+	sort.SliceStable(managedSnapshots, func(i, j int) bool {
+		return managedSnapshots[i].Properties.CreateTime.After(managedSnapshots[j].Properties.CreateTime.Time)
+	})
+	return managedSnapshots, nil
+}
+
+// createRotationSnapshot creates a new snapshot managed by a snapshot rotation
+func createRotationSnapshot(ctx context.Context, client *gsclient.Client, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+	labels := append(convSOStrings(d.Get("labels").(*schema.Set).List()), getRotationSnapshotLabel(name))
+	requestBody := gsclient.StorageSnapshotCreateRequest{
+		Name:   fmt.Sprintf("%s-%s", name, d.Get("trigger").(string)),
+		Labels: labels,
+	}
+	response, err := client.CreateStorageSnapshot(ctx, d.Get("storage_uuid").(string), requestBody)
+	if err != nil {
+		return err
+	}
+	log.Printf("Snapshot %s (%s) has been created by snapshot rotation %s", requestBody.Name, response.ObjectUUID, name)
+	return nil
+}
+
+// pruneRotationSnapshots deletes the managed snapshots which exceed the keep and max_age policies.
+// The latest snapshot is never deleted.
+func pruneRotationSnapshots(ctx context.Context, client *gsclient.Client, d *schema.ResourceData) error {
+	storageUUID := d.Get("storage_uuid").(string)
+	snapshots, err := getRotationSnapshots(ctx, client, storageUUID, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+	keep := d.Get("keep").(int)
+	var maxAge time.Duration
+	if attr, ok := d.GetOk("max_age"); ok {
+		if maxAge, err = time.ParseDuration(attr.(string)); err != nil {
+			return err
+		}
+	}
+	for i, snapshot := range snapshots {
+		if i == 0 {
+			continue
+		}
+		exceedsKeep := keep > 0 && i >= keep
+		exceedsMaxAge := maxAge > 0 && time.Since(snapshot.Properties.CreateTime.Time) > maxAge
+		if !exceedsKeep && !exceedsMaxAge {
+			continue
+		}
+		log.Printf("Deleting snapshot %s (%s) of snapshot rotation %s", snapshot.Properties.Name, snapshot.Properties.ObjectUUID, d.Id())
+		err = errHandler.SuppressHTTPErrorCodes(
+			client.DeleteStorageSnapshot(ctx, storageUUID, snapshot.Properties.ObjectUUID),
+			http.StatusNotFound,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
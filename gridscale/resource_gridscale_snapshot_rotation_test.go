@@ -0,0 +1,60 @@
+package gridscale
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscaleSnapshotRotation_Basic(t *testing.T) {
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleSnapshotRotationConfig_basic(name, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_snapshot_rotation.foo", "snapshot_uuids.#", "1"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_snapshot_rotation.foo", "latest_snapshot_uuid"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleSnapshotRotationConfig_basic(name, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_snapshot_rotation.foo", "snapshot_uuids.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleSnapshotRotationConfig_basic(name, "v3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_snapshot_rotation.foo", "snapshot_uuids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleSnapshotRotationConfig_basic(name, trigger string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = 1
+}
+resource "gridscale_snapshot_rotation" "foo" {
+  name = "%s"
+  storage_uuid = gridscale_storage.foo.id
+  trigger = "%s"
+  keep = 2
+  delete_snapshots_on_destroy = true
+}
+`, name, name, trigger)
+}
@@ -35,6 +35,8 @@ func TestAccResourceGridscaleSnapshot_Basic(t *testing.T) {
 					testAccCheckDataSourceGridscaleSnapshotExists("gridscale_snapshot.foo", &object),
 					resource.TestCheckResourceAttr(
 						"gridscale_snapshot.foo", "name", "newname"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"gridscale_snapshot.foo", "rollback.*", map[string]string{"id": "second", "status": "success"}),
 				),
 			},
 			{
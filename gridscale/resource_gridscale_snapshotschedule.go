@@ -16,7 +16,7 @@ import (
 )
 
 func resourceGridscaleStorageSnapshotSchedule() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscaleSnapshotScheduleCreate,
 		Read:   resourceGridscaleSnapshotScheduleRead,
 		Delete: resourceGridscaleSnapshotScheduleDelete,
@@ -33,6 +33,7 @@ func resourceGridscaleStorageSnapshotSchedule() *schema.Resource {
 			"next_runtime": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "The date and time that the snapshot schedule will be run",
 			},
 			"next_runtime_computed": {
@@ -48,9 +49,10 @@ func resourceGridscaleStorageSnapshotSchedule() *schema.Resource {
 			},
 			"run_interval": {
 				Type:         schema.TypeInt,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(60),
-				Description:  "The interval at which the schedule will run (in minutes)",
+				Description:  "The interval at which the schedule will run (in minutes). Required if cron is not set.",
 			},
 			"storage_uuid": {
 				Type:        schema.TypeString,
@@ -106,7 +108,12 @@ func resourceGridscaleStorageSnapshotSchedule() *schema.Resource {
 			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return customizeScheduleCronDiff(d, false)
+		},
 	}
+	addScheduleCronSchema(resource.Schema)
+	return resource
 }
 
 func resourceGridscaleSnapshotScheduleRead(d *schema.ResourceData, meta interface{}) error {
@@ -133,6 +140,13 @@ func resourceGridscaleSnapshotScheduleRead(d *schema.ResourceData, meta interfac
 	if err = d.Set("next_runtime_computed", props.NextRuntime.Format(timeLayout)); err != nil {
 		return fmt.Errorf("%s error setting next_runtime_computed: %v", errorPrefix, err)
 	}
+	// If the schedule is defined by a cron expression, next_runtime is computed as well.
+	// It is re-aligned with the cron expression by CustomizeDiff if it drifted.
+	if d.Get("cron").(string) != "" {
+		if err = d.Set("next_runtime", props.NextRuntime.Format(timeLayout)); err != nil {
+			return fmt.Errorf("%s error setting next_runtime: %v", errorPrefix, err)
+		}
+	}
 	if err = d.Set("keep_snapshots", props.KeepSnapshots); err != nil {
 		return fmt.Errorf("%s error setting keep_snapshots: %v", errorPrefix, err)
 	}
@@ -3,6 +3,7 @@ package gridscale
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -142,3 +143,54 @@ resource "gridscale_snapshotschedule" "foo" {
 }
 `)
 }
+
+func TestAccResourceGridscaleSnapshotSchedule_Cron(t *testing.T) {
+	var object gsclient.StorageSnapshotSchedule
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataSourceGridscaleSnapshotScheduleDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourceGridscaleSnapshotScheduleConfig_cron(name, "30 2 * * *"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceGridscaleSnapshotScheduleExists("gridscale_snapshotschedule.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_snapshotschedule.foo", "run_interval", "1440"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_snapshotschedule.foo", "next_runtime"),
+				),
+			},
+			{
+				Config: testAccCheckDataSourceGridscaleSnapshotScheduleConfig_cron(name, "0 */6 * * *"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceGridscaleSnapshotScheduleExists("gridscale_snapshotschedule.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_snapshotschedule.foo", "run_interval", "360"),
+				),
+			},
+			{
+				Config:      testAccCheckDataSourceGridscaleSnapshotScheduleConfig_cron(name, "0 3 * * MON-FRI"),
+				ExpectError: regexp.MustCompile("not evenly spaced"),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceGridscaleSnapshotScheduleConfig_cron(name, cron string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "storage"
+  capacity = 1
+}
+resource "gridscale_snapshotschedule" "foo" {
+  name = "%s"
+  storage_uuid = gridscale_storage.foo.id
+  keep_snapshots = 1
+  cron = "%s"
+  timezone = "Europe/Berlin"
+}
+`, name, cron)
+}
@@ -23,7 +23,7 @@ const (
 )
 
 func resourceGridscaleMSSQLServer() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Create: resourceGridscaleMSSQLServerCreate,
 		Read:   resourceGridscaleMSSQLServerRead,
 		Delete: resourceGridscaleMSSQLServerDelete,
@@ -60,6 +60,12 @@ func resourceGridscaleMSSQLServer() *schema.Resource {
 				}
 				return errors.New(errMess)
 			}
+			if err := customizePaaSTemplateChangeDiff(d, paasTemplates, chosenTemplate); err != nil {
+				return err
+			}
+			if err := validatePaaSAutoscaling(d, chosenTemplate); err != nil {
+				return err
+			}
 			return validateMSSQLParameters(d, chosenTemplate)
 		},
 		Schema: map[string]*schema.Schema{
@@ -72,7 +78,7 @@ func resourceGridscaleMSSQLServer() *schema.Resource {
 			"release": {
 				Type: schema.TypeString,
 				Description: `The MS SQL Server release of this instance.\n
-				For convenience, please use gscloud https://github.com/gridscale/gscloud to get the list of available MS SQL Server releases.`,
+				Use the gridscale_paas_templates data source (or gscloud https://github.com/gridscale/gscloud) to get the list of available MS SQL Server releases.`,
 				Required:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
@@ -229,6 +235,10 @@ func resourceGridscaleMSSQLServer() *schema.Resource {
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 	}
+	addPaaSAutoscalingSchema(resource.Schema)
+	addPaaSTemplateChangeSchema(resource.Schema)
+	addPaaSCredentialRotation(resource, "username", "password")
+	return resource
 }
 
 func resourceGridscaleMSSQLServerRead(d *schema.ResourceData, meta interface{}) error {
@@ -312,6 +322,11 @@ func resourceGridscaleMSSQLServerRead(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	//Get autoscaling limits
+	if err = setPaaSAutoscaling(client, d, props); err != nil {
+		return fmt.Errorf("%s error setting autoscaling: %v", errorPrefix, err)
+	}
+
 	//Set labels
 	if err = d.Set("labels", props.Labels); err != nil {
 		return fmt.Errorf("%s error setting labels: %v", errorPrefix, err)
@@ -377,6 +392,10 @@ func resourceGridscaleMSSQLServerCreate(d *schema.ResourceData, meta interface{}
 		requestBody.Parameters = params
 	}
 
+	if limits := expandPaaSAutoscalingLimits(d, false); len(limits) > 0 {
+		requestBody.ResourceLimits = limits
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
 	response, err := client.CreatePaaSService(ctx, requestBody)
@@ -422,12 +441,21 @@ func resourceGridscaleMSSQLServerUpdate(d *schema.ResourceData, meta interface{}
 	}
 	requestBody.Parameters = params
 
+	if limits := expandPaaSAutoscalingLimits(d, false); len(limits) > 0 {
+		requestBody.ResourceLimits = limits
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 	err := client.UpdatePaaSService(ctx, d.Id(), requestBody)
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
+	if d.HasChange("rotate_credentials") {
+		if err = renewPaaSCredentials(ctx, client, d.Id()); err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+	}
 	return resourceGridscaleMSSQLServerRead(d, meta)
 }
 
@@ -2,6 +2,7 @@ package gridscale
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -27,10 +28,25 @@ func resourceGridscaleStorage() *schema.Resource {
 		},
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
 			storageVariant := d.Get("storage_variant").(string)
-			if storageVariant == "local" {
+			if storageVariant == localStorageVariant {
 				if d.HasChange("storage_type") {
-					return errors.New("storage_type cannot be set when storage_variant is set to \"local\"")
+					return errors.New("storage_type cannot be set when storage_variant is set to \"local\", rocket storages have a fixed performance class")
 				}
+				// location_uuid is computed, so it is unknown when the storage is (re)created. A replacement
+				// is checked against the location of the existing storage, a new storage against the locations of the project.
+				if d.Id() == "" || d.HasChange("storage_variant") {
+					oldLocationUUID, _ := d.GetChange("location_uuid")
+					if err := validateRocketStorageAvailability(ctx, meta.(*gsclient.Client), oldLocationUUID.(string)); err != nil {
+						return err
+					}
+				}
+			}
+			if err := customizeStorageCapacityDiff(d); err != nil {
+				return err
+			}
+			// Template parameters are only used when the storage is (re)created
+			if d.Id() == "" || d.HasChange("template") {
+				return validateStorageTemplateParams(ctx, d, meta.(*gsclient.Client))
 			}
 			return nil
 		},
@@ -72,7 +88,7 @@ func resourceGridscaleStorage() *schema.Resource {
 			},
 			"storage_variant": {
 				Type:        schema.TypeString,
-				Description: "Storage variant (one of local or distributed).",
+				Description: "Storage variant (one of local or distributed). Local storages (rocket storages) are placed on the local disks of a host, they can only be attached to servers in the same availability zone.",
 				Optional:    true,
 				ForceNew:    true,
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
@@ -84,7 +100,7 @@ func resourceGridscaleStorage() *schema.Resource {
 						}
 					}
 					if !valid {
-						errors = append(errors, fmt.Errorf("%v is not a valid storage variant. Valid variants are: %v", v.(string), strings.Join(storageTypes, ",")))
+						errors = append(errors, fmt.Errorf("%v is not a valid storage variant. Valid variants are: %v", v.(string), strings.Join(storageVariants, ",")))
 					}
 					return
 				},
@@ -193,13 +209,36 @@ func resourceGridscaleStorage() *schema.Resource {
 							ForceNew: true,
 							Required: true,
 						},
+						"user_data": {
+							Type:          schema.TypeString,
+							Description:   "Cloud-init user data (plain text) which is used to configure the server on first boot. Only supported by templates with cloud-init.",
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"template.0.user_data_base64"},
+							ValidateFunc:  validateStorageTemplateUserData,
+						},
+						"user_data_base64": {
+							Type:          schema.TypeString,
+							Description:   "Base64 encoded cloud-init user data which is used to configure the server on first boot. Only supported by templates with cloud-init.",
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"template.0.user_data"},
+							ValidateFunc:  validateStorageTemplateUserDataBase64,
+						},
 					},
 				},
 			},
+			"allow_shrink_by_replace": {
+				Type:        schema.TypeBool,
+				Description: "Replace the storage if its capacity is decreased, since shrinking a storage is not supported. All data of the storage is lost.",
+				Optional:    true,
+				Default:     false,
+			},
 			"rollback_from_backup_uuid": {
 				Type:        schema.TypeString,
 				Description: "Rollback the storage from a specific storage backup.",
 				Optional:    true,
+				Deprecated:  "Use the gridscale_storage_backup_restore resource instead.",
 			},
 		},
 		Timeouts: &schema.ResourceTimeout{
@@ -303,7 +342,26 @@ func resourceGridscaleStorageUpdate(d *schema.ResourceData, meta interface{}) er
 
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
-	err := client.UpdateStorage(ctx, d.Id(), requestBody)
+	updateStorage := func(ctx context.Context) error {
+		return client.UpdateStorage(ctx, d.Id(), requestBody)
+	}
+	var err error
+	// Servers which cannot hotplug storages have to be off while the storage grows
+	if oldCapacity, newCapacity := d.GetChange("capacity"); newCapacity.(int) > oldCapacity.(int) {
+		var serverUUIDs []string
+		serverUUIDs, err = getStorageServersWithoutHotplug(ctx, client, d.Id())
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		if len(serverUUIDs) > 0 {
+			log.Printf("[INFO] Servers %v cannot hotplug storage %s, they are shut down during the resize", serverUUIDs, d.Id())
+			err = runActionRequireServersOff(ctx, client, d.Id(), serverUUIDs, updateStorage)
+		} else {
+			err = updateStorage(ctx)
+		}
+	} else {
+		err = updateStorage(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("%s error: %v", errorPrefix, err)
 	}
@@ -375,10 +433,26 @@ func resourceGridscaleStorageCreate(d *schema.ResourceData, meta interface{}) er
 		requestBody.Template = &template
 	}
 
+	//cloud-init user data is sent base64 encoded
+	userData := d.Get("template.0.user_data_base64").(string)
+	if attr, ok := d.GetOk("template.0.user_data"); ok {
+		userData = base64.StdEncoding.EncodeToString([]byte(attr.(string)))
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
-	response, err := client.CreateStorage(ctx, requestBody)
+	var response gsclient.CreateResponse
+	var err error
+	if userData != "" {
+		response, err = createStorageWithUserData(ctx, client, requestBody, userData)
+	} else {
+		response, err = client.CreateStorage(ctx, requestBody)
+	}
 	if err != nil {
+		//Keep the storage in the state if it was created, but could not be set up completely
+		if response.ObjectUUID != "" {
+			d.SetId(response.ObjectUUID)
+		}
 		return err
 	}
 
@@ -442,3 +516,155 @@ func resourceGridscaleStorageDelete(d *schema.ResourceData, meta interface{}) er
 	}
 	return nil
 }
+
+// validateStorageTemplateParams validates the parameters of the template block
+// against the properties of the chosen template.
+func validateStorageTemplateParams(ctx context.Context, d *schema.ResourceDiff, client *gsclient.Client) error {
+	templateUUID := d.Get("template.0.template_uuid").(string)
+	if templateUUID == "" || !d.NewValueKnown("template.0.template_uuid") {
+		return nil
+	}
+	template, err := client.GetTemplate(ctx, templateUUID)
+	if err != nil {
+		return fmt.Errorf("error getting template (%s): %v", templateUUID, err)
+	}
+	props := template.Properties
+	password := d.Get("template.0.password").(string)
+	passwordType := d.Get("template.0.password_type").(string)
+	hostname := d.Get("template.0.hostname").(string)
+	sshkeys := d.Get("template.0.sshkeys").([]interface{})
+	sshkeysKnown := d.NewValueKnown("template.0.sshkeys")
+
+	if d.NewValueKnown("capacity") && d.Get("capacity").(int) < props.Capacity {
+		return fmt.Errorf("capacity (%d GB) is smaller than the capacity of template %s (%d GB). Please set capacity to at least %d", d.Get("capacity").(int), props.Name, props.Capacity, props.Capacity)
+	}
+	if password != "" && passwordType == "" {
+		return errors.New("template.0.password_type is required when template.0.password is set. Valid types are: " + strings.Join(passwordTypes, ","))
+	}
+	if props.Private {
+		// Private templates are copied as they are, no parameters are applied
+		if password != "" || len(sshkeys) > 0 || hostname != "" {
+			return fmt.Errorf("template %s is a private template: password, sshkeys and hostname are only supported by public templates. Please remove them from the template block", props.Name)
+		}
+	} else if strings.EqualFold(props.Ostype, "windows") {
+		if len(sshkeys) > 0 {
+			return fmt.Errorf("template %s is a Windows template: sshkeys are only supported by public Linux templates. Please use password and password_type instead", props.Name)
+		}
+		if hostname != "" {
+			return fmt.Errorf("template %s is a Windows template: hostname is not supported by public Windows templates. Please remove it from the template block", props.Name)
+		}
+		if password == "" && d.NewValueKnown("template.0.password") {
+			return fmt.Errorf("template %s is a Windows template: password and password_type are required to set the Administrator password", props.Name)
+		}
+	} else if password == "" && len(sshkeys) == 0 && sshkeysKnown && d.NewValueKnown("template.0.password") {
+		return fmt.Errorf("template %s is a public template: either password and password_type or at least one SSH key in sshkeys is required to access the storage", props.Name)
+	}
+
+	// Show the license which is required by the template in the plan
+	if props.LicenseProductNo != 0 {
+		log.Printf("[INFO] template %s requires a license (product no. %d), the license is billed additionally", props.Name, props.LicenseProductNo)
+		return d.SetNew("license_product_no", props.LicenseProductNo)
+	}
+	return nil
+}
+
+// waitForStorageActive waits until the status of a storage is active
+func waitForStorageActive(ctx context.Context, client *gsclient.Client, id string) error {
+	for {
+		storage, err := client.GetStorage(ctx, id)
+		if err != nil {
+			return err
+		}
+		if storage.Properties.Status == "active" {
+			return nil
+		}
+		log.Printf("[DEBUG] Waiting for storage (%s) to be active, current status: %s", id, storage.Properties.Status)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for storage (%s) to be active: %v", id, ctx.Err())
+		case <-time.After(client.DelayInterval()):
+		}
+	}
+}
+
+// runActionRequireStorageServersOff runs an action on a storage (e.g. a rollback) while all servers
+// which the storage is attached to are off. The servers are started again (if they were on)
+// after the action is done and the storage is active again.
+func runActionRequireStorageServersOff(ctx context.Context, client *gsclient.Client, storageUUID string, storageAction actionRequireServerOff) error {
+	storage, err := client.GetStorage(ctx, storageUUID)
+	if err != nil {
+		return err
+	}
+	serverUUIDs := make([]string, 0)
+	for _, server := range storage.Properties.Relations.Servers {
+		serverUUIDs = append(serverUUIDs, server.ObjectUUID)
+	}
+	return runActionRequireServersOff(ctx, client, storageUUID, serverUUIDs, storageAction)
+}
+
+// runActionRequireServersOff runs an action on a storage while the given servers are off.
+// The servers are started again (if they were on) after the action is done and the storage is active again.
+func runActionRequireServersOff(ctx context.Context, client *gsclient.Client, storageUUID string, serverUUIDs []string, storageAction actionRequireServerOff) error {
+	action := func(ctx context.Context) error {
+		if err := storageAction(ctx); err != nil {
+			return err
+		}
+		return waitForStorageActive(ctx, client, storageUUID)
+	}
+	for _, serverUUID := range serverUUIDs {
+		serverUUID := serverUUID
+		innerAction := action
+		action = func(ctx context.Context) error {
+			return globalServerStatusList.runActionRequireServerOff(ctx, client, serverUUID, false, innerAction)
+		}
+	}
+	return action(ctx)
+}
+
+// storageHotplugUnsupportedHardwareProfiles are the hardware profiles of servers
+// which cannot hotplug storages (e.g. resize them while running).
+var storageHotplugUnsupportedHardwareProfiles = []string{string(gsclient.LegacyServerHardware)}
+
+// getStorageServersWithoutHotplug returns the UUIDs of the servers which the storage is attached to
+// and which cannot hotplug storages.
+func getStorageServersWithoutHotplug(ctx context.Context, client *gsclient.Client, storageUUID string) ([]string, error) {
+	storage, err := client.GetStorage(ctx, storageUUID)
+	if err != nil {
+		return nil, err
+	}
+	serverUUIDs := make([]string, 0)
+	for _, serverRel := range storage.Properties.Relations.Servers {
+		server, err := client.GetServer(ctx, serverRel.ObjectUUID)
+		if err != nil {
+			return nil, err
+		}
+		if server.Properties.Legacy {
+			serverUUIDs = append(serverUUIDs, serverRel.ObjectUUID)
+			continue
+		}
+		for _, profile := range storageHotplugUnsupportedHardwareProfiles {
+			if server.Properties.HardwareProfile == profile {
+				serverUUIDs = append(serverUUIDs, serverRel.ObjectUUID)
+				break
+			}
+		}
+	}
+	return serverUUIDs, nil
+}
+
+// customizeStorageCapacityDiff forbids decreasing the capacity of a storage, since the gridscale API
+// does not support shrinking storages. If allow_shrink_by_replace is true, the storage is replaced instead.
+func customizeStorageCapacityDiff(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChange("capacity") || !d.NewValueKnown("capacity") {
+		return nil
+	}
+	oldCapacity, newCapacity := d.GetChange("capacity")
+	if newCapacity.(int) >= oldCapacity.(int) {
+		return nil
+	}
+	if d.Get("allow_shrink_by_replace").(bool) {
+		return d.ForceNew("capacity")
+	}
+	return fmt.Errorf("capacity of storage (%s) cannot be decreased from %d GB to %d GB, set allow_shrink_by_replace to replace the storage instead (all data of the storage is lost)",
+		d.Id(), oldCapacity.(int), newCapacity.(int))
+}
@@ -0,0 +1,243 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func resourceGridscaleStorageBackupPrune() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscaleStorageBackupPruneCreate,
+		Read:   resourceGridscaleStorageBackupPruneRead,
+		Update: resourceGridscaleStorageBackupPruneUpdate,
+		Delete: resourceGridscaleStorageBackupPruneDelete,
+		Schema: map[string]*schema.Schema{
+			"storage_uuid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: []string{"storage_uuid", "labels"},
+				Description:  "UUID of the storage whose backups are pruned.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"labels": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: []string{"storage_uuid", "labels"},
+				Description:  "Only backups of storages which have all of the labels are pruned.",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Additionally, only backups whose names match the regular expression are pruned. The name of a backup starts with the name of its backup schedule.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"keep": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: []string{"keep", "max_age"},
+				Description:  "The maximum number of (matching) backups to keep. The oldest backups are deleted first.",
+			},
+			"max_age": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := time.ParseDuration(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%v is not a valid duration (e.g. \"720h\"): %v", v.(string), err))
+					}
+					return
+				},
+				AtLeastOneOf: []string{"keep", "max_age"},
+				Description:  "The maximum age of (matching) backups (e.g. \"720h\"). Older backups are deleted, the latest backup is always kept.",
+			},
+			"trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The backups are pruned again every time the trigger changes. It can be any string value.",
+			},
+			"deleted_backup_uuids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "UUIDs of the backups which were deleted by the last pruning.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleStorageBackupPruneRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("read storage backup prune (%s) resource -", d.Id())
+	storageUUID := d.Get("storage_uuid").(string)
+	// Without storage_uuid, the storages are selected by labels when pruning
+	if storageUUID == "" {
+		return nil
+	}
+	_, err := client.GetStorage(context.Background(), storageUUID)
+	if err != nil {
+		if requestError, ok := err.(gsclient.RequestError); ok {
+			if requestError.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscaleStorageBackupPruneCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	id := d.Get("storage_uuid").(string)
+	if id == "" {
+		labels := convSOStrings(d.Get("labels").(*schema.Set).List())
+		sort.Strings(labels)
+		id = fmt.Sprintf("storage_backup_prune/%s", strings.Join(labels, ","))
+	}
+	errorPrefix := fmt.Sprintf("prune storage backups (%s) resource -", id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	if err := pruneStorageBackups(ctx, client, d); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	d.SetId(id)
+	return resourceGridscaleStorageBackupPruneRead(d, meta)
+}
+
+func resourceGridscaleStorageBackupPruneUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("update storage backup prune (%s) resource -", d.Id())
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	if err := pruneStorageBackups(ctx, client, d); err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return resourceGridscaleStorageBackupPruneRead(d, meta)
+}
+
+func resourceGridscaleStorageBackupPruneDelete(d *schema.ResourceData, meta interface{}) error {
+	// Deleted backups cannot be restored, the resource is only removed from the state
+	log.Printf("[INFO] storage backup prune (%s) is removed from the state", d.Id())
+	return nil
+}
+
+// pruneStorageBackups deletes the backups of the selected storages which exceed the keep and max_age
+// policies. Storages are selected by storage_uuid and/or labels. Only backups whose names match
+// name_regex (if it is set) are considered, the latest of them is never deleted.
+func pruneStorageBackups(ctx context.Context, client *gsclient.Client, d *schema.ResourceData) error {
+	storageUUIDs, err := getStorageBackupPruneStorageUUIDs(ctx, client, d)
+	if err != nil {
+		return err
+	}
+	var nameRegex *regexp.Regexp
+	if attr, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(attr.(string))
+	}
+	var maxAge time.Duration
+	if attr, ok := d.GetOk("max_age"); ok {
+		if maxAge, err = time.ParseDuration(attr.(string)); err != nil {
+			return err
+		}
+	}
+	keep := d.Get("keep").(int)
+
+	deletedBackupUUIDs := make([]string, 0)
+	for _, storageUUID := range storageUUIDs {
+		backups, err := client.GetStorageBackupList(ctx, storageUUID)
+		if err != nil {
+			return err
+		}
+		matchingBackups := make([]gsclient.StorageBackup, 0)
+		for _, backup := range backups {
+			if nameRegex == nil || nameRegex.MatchString(backup.Properties.Name) {
+				matchingBackups = append(matchingBackups, backup)
+			}
+		}
+		//Sort the backups by create_time, latest backup first
+		sort.SliceStable(matchingBackups, func(i, j int) bool {
+			return matchingBackups[i].Properties.CreateTime.After(matchingBackups[j].Properties.CreateTime.Time)
+		})
+
+		for i, backup := range matchingBackups {
+			if i == 0 {
+				continue
+			}
+			exceedsKeep := keep > 0 && i >= keep
+			exceedsMaxAge := maxAge > 0 && time.Since(backup.Properties.CreateTime.Time) > maxAge
+			if !exceedsKeep && !exceedsMaxAge {
+				continue
+			}
+			log.Printf("Deleting backup %s (%s) of storage %s", backup.Properties.Name, backup.Properties.ObjectUUID, storageUUID)
+			err = errHandler.SuppressHTTPErrorCodes(
+				client.DeleteStorageBackup(ctx, storageUUID, backup.Properties.ObjectUUID),
+				http.StatusNotFound,
+			)
+			if err != nil {
+				return err
+			}
+			deletedBackupUUIDs = append(deletedBackupUUIDs, backup.Properties.ObjectUUID)
+		}
+	}
+	return d.Set("deleted_backup_uuids", deletedBackupUUIDs)
+}
+
+// getStorageBackupPruneStorageUUIDs returns the UUIDs of the storages whose backups are pruned:
+// the storage of storage_uuid and/or all storages which have all labels.
+// Storage backups have no labels, so the labels of their storages are used.
+func getStorageBackupPruneStorageUUIDs(ctx context.Context, client *gsclient.Client, d *schema.ResourceData) ([]string, error) {
+	storageUUID := d.Get("storage_uuid").(string)
+	labels := convSOStrings(d.Get("labels").(*schema.Set).List())
+	if len(labels) == 0 {
+		return []string{storageUUID}, nil
+	}
+	storages, err := client.GetStorageList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	storageUUIDs := make([]string, 0)
+	for _, storage := range storages {
+		if storageUUID != "" && storage.Properties.ObjectUUID != storageUUID {
+			continue
+		}
+		if hasAllLabels(storage.Properties.Labels, labels) {
+			storageUUIDs = append(storageUUIDs, storage.Properties.ObjectUUID)
+		}
+	}
+	return storageUUIDs, nil
+}
+
+// hasAllLabels checks if labels contains all of the required labels
+func hasAllLabels(labels, requiredLabels []string) bool {
+	labelSet := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		labelSet[label] = true
+	}
+	for _, label := range requiredLabels {
+		if !labelSet[label] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package gridscale
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscaleStorageBackupPrune_Basic(t *testing.T) {
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleStorageBackupPruneConfig_basic(name, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"gridscale_storage_backup_prune.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_backup_prune.foo", "deleted_backup_uuids.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleStorageBackupPruneConfig_basic(name, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_backup_prune.foo", "trigger", "v2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscaleStorageBackupPrune_Labels(t *testing.T) {
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleStorageBackupPruneConfig_labels(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_backup_prune.foo", "id", fmt.Sprintf("storage_backup_prune/%s", name)),
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_backup_prune.foo", "deleted_backup_uuids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleStorageBackupPruneConfig_basic(name, trigger string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = 1
+}
+resource "gridscale_storage_backup_prune" "foo" {
+  storage_uuid = gridscale_storage.foo.id
+  name_regex = "^%s"
+  keep = 3
+  max_age = "720h"
+  trigger = "%s"
+}
+`, name, name, trigger)
+}
+
+func testAccCheckResourceGridscaleStorageBackupPruneConfig_labels(name string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = 1
+  labels = ["%s"]
+}
+resource "gridscale_storage_backup_prune" "foo" {
+  labels = gridscale_storage.foo.labels
+  keep = 3
+}
+`, name, name)
+}
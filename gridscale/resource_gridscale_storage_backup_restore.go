@@ -0,0 +1,103 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func resourceGridscaleStorageBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGridscaleStorageBackupRestoreCreate,
+		Read:   resourceGridscaleStorageBackupRestoreRead,
+		Delete: resourceGridscaleStorageBackupRestoreDelete,
+		Schema: map[string]*schema.Schema{
+			"storage_uuid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "UUID of the storage which is restored.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"backup_uuid": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "UUID of the backup which the storage is restored from.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The storage is restored again every time the trigger changes. It can be any string value.",
+			},
+			"restore_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the storage was restored.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleStorageBackupRestoreRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	errorPrefix := fmt.Sprintf("read storage backup restore (%s) resource -", d.Id())
+	// The restore is kept as long as the storage exists
+	_, err := client.GetStorage(context.Background(), d.Get("storage_uuid").(string))
+	if err != nil {
+		if requestError, ok := err.(gsclient.RequestError); ok {
+			if requestError.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	return nil
+}
+
+func resourceGridscaleStorageBackupRestoreCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	storageUUID := d.Get("storage_uuid").(string)
+	backupUUID := d.Get("backup_uuid").(string)
+	errorPrefix := fmt.Sprintf("restore storage (%s) from backup (%s) resource -", storageUUID, backupUUID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	log.Printf("Start restoring storage %s from backup %s", storageUUID, backupUUID)
+	//All servers which the storage is attached to have to be off during the restore
+	err := runActionRequireStorageServersOff(ctx, client, storageUUID, func(ctx context.Context) error {
+		return client.RollbackStorageBackup(ctx, storageUUID, backupUUID, gsclient.StorageRollbackRequest{
+			Rollback: true,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("%s error: %v", errorPrefix, err)
+	}
+	log.Printf("Restoring storage %s from backup %s SUCCESSFULLY", storageUUID, backupUUID)
+
+	id := fmt.Sprintf("%s/%s", storageUUID, backupUUID)
+	d.SetId(id)
+	if err = d.Set("restore_time", time.Now().Format(timeLayout)); err != nil {
+		return fmt.Errorf("%s error setting restore_time: %v", errorPrefix, err)
+	}
+	return resourceGridscaleStorageBackupRestoreRead(d, meta)
+}
+
+func resourceGridscaleStorageBackupRestoreDelete(d *schema.ResourceData, meta interface{}) error {
+	// A restore cannot be undone, the resource is only removed from the state
+	log.Printf("[INFO] storage backup restore (%s) is removed from the state, the storage is not changed", d.Id())
+	return nil
+}
@@ -0,0 +1,40 @@
+package gridscale
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGridscaleStorageBackupRestore_UnknownBackup(t *testing.T) {
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckResourceGridscaleStorageBackupRestoreConfig_basic(name, "00000000-0000-0000-0000-000000000000"),
+				ExpectError: regexp.MustCompile("restore storage .* from backup .* resource"),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleStorageBackupRestoreConfig_basic(name, backupUUID string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = 1
+}
+resource "gridscale_storage_backup_restore" "foo" {
+  storage_uuid = gridscale_storage.foo.id
+  backup_uuid = "%s"
+  trigger = "v1"
+}
+`, name, backupUUID)
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
 
 	"github.com/gridscale/gsclient-go/v3"
 )
@@ -17,7 +19,7 @@ func resourceGridscaleStorageClone() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGridscaleStorageCloneCreate,
 		Read:   resourceGridscaleStorageRead,
-		Delete: resourceGridscaleStorageDelete,
+		Delete: resourceGridscaleStorageCloneDelete,
 		Update: resourceGridscaleStorageUpdate,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -26,10 +28,31 @@ func resourceGridscaleStorageClone() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"source_storage_id": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				ForceNew:     true,
 				Description:  "ID of the storage instance that will be cloned.",
 				ValidateFunc: validation.NoZeroValues,
+				AtLeastOneOf: []string{"source_storage_id", "source_snapshot_uuid"},
+			},
+			"source_snapshot_uuid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "UUID of the snapshot the storage is cloned from instead of the live storage.",
+				ValidateFunc: validation.NoZeroValues,
+				AtLeastOneOf: []string{"source_storage_id", "source_snapshot_uuid"},
+			},
+			"keep_template": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Keep the intermediate template created from the snapshot (see template_uuid), e.g. to create further storages from it. The template is deleted with the storage clone.",
+			},
+			"template_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the intermediate template, if keep_template is true.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -45,8 +68,10 @@ func resourceGridscaleStorageClone() *schema.Resource {
 			},
 			"location_uuid": {
 				Type:        schema.TypeString,
-				Description: "Identifies the data center this object belongs to.",
+				Description: "Identifies the data center this object belongs to. By default, the storage is cloned into the location of the source storage.",
+				Optional:    true,
 				Computed:    true,
+				ForceNew:    true,
 			},
 			"storage_type": {
 				Type:        schema.TypeString,
@@ -124,9 +149,18 @@ func resourceGridscaleStorageClone() *schema.Resource {
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"allow_shrink_by_replace": {
+				Type:        schema.TypeBool,
+				Description: "Clone the source again if the capacity is decreased, since shrinking a storage is not supported. All data of the storage clone is lost.",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return customizeStorageCapacityDiff(d)
 		},
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(5 * time.Minute),
+			Create: schema.DefaultTimeout(15 * time.Minute),
 			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
@@ -138,6 +172,28 @@ func resourceGridscaleStorageCloneCreate(d *schema.ResourceData, meta interface{
 	srcStorageID := d.Get("source_storage_id").(string)
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
+
+	// CloneStorage only clones a live storage into its own location. Clones of snapshots
+	// and clones into other locations are created from an intermediate template.
+	needTemplate := d.Get("source_snapshot_uuid").(string) != ""
+	var sourceStorage gsclient.Storage
+	if srcStorageID != "" {
+		var err error
+		sourceStorage, err = client.GetStorage(ctx, srcStorageID)
+		if err != nil {
+			return err
+		}
+		if locationUUID, ok := d.GetOk("location_uuid"); ok && locationUUID.(string) != sourceStorage.Properties.LocationUUID {
+			needTemplate = true
+		}
+	}
+	if needTemplate {
+		if err := createStorageCloneFromTemplate(ctx, d, client, sourceStorage); err != nil {
+			return err
+		}
+		return resourceGridscaleStorageRead(d, meta)
+	}
+
 	response, err := client.CloneStorage(ctx, srcStorageID)
 	if err != nil {
 		return err
@@ -150,10 +206,6 @@ func resourceGridscaleStorageCloneCreate(d *schema.ResourceData, meta interface{
 	// If the user wants a new name and new capacity for the storage clone
 	// instead of the default values inherited from the source storage,
 	// change name and capacity of the storage clone to the desired ones.
-	sourceStorage, err := client.GetStorage(ctx, srcStorageID)
-	if err != nil {
-		return err
-	}
 	if sourceStorage.Properties.Name != d.Get("name").(string) ||
 		sourceStorage.Properties.Capacity != d.Get("capacity").(int) ||
 		sourceStorage.Properties.StorageType != d.Get("storage_type").(string) {
@@ -165,3 +217,149 @@ func resourceGridscaleStorageCloneCreate(d *schema.ResourceData, meta interface{
 
 	return resourceGridscaleStorageRead(d, meta)
 }
+
+func resourceGridscaleStorageCloneDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	if err := resourceGridscaleStorageDelete(d, meta); err != nil {
+		return err
+	}
+	// Delete the intermediate template which was kept (see keep_template)
+	templateUUID := d.Get("template_uuid").(string)
+	if templateUUID == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	err := errHandler.SuppressHTTPErrorCodes(
+		client.DeleteTemplate(ctx, templateUUID),
+		http.StatusNotFound,
+	)
+	if err != nil {
+		return fmt.Errorf("delete storage clone (%s) resource - error deleting template (%s): %v", d.Id(), templateUUID, err)
+	}
+	return nil
+}
+
+// createStorageCloneFromTemplate clones a storage (or a snapshot of it) via snapshot -> template -> storage.
+// A temporary snapshot is created if no source snapshot is given. The temporary snapshot and
+// the intermediate template are deleted afterwards, also if the clone fails. If keep_template is true,
+// the template is only kept after a successful clone.
+func createStorageCloneFromTemplate(ctx context.Context, d *schema.ResourceData, client *gsclient.Client, sourceStorage gsclient.Storage) (err error) {
+	srcStorageID := d.Get("source_storage_id").(string)
+	snapshotUUID := d.Get("source_snapshot_uuid").(string)
+	suffix := time.Now().Format("20060102150405")
+
+	if snapshotUUID == "" {
+		snapshot, err := client.CreateStorageSnapshot(ctx, srcStorageID, gsclient.StorageSnapshotCreateRequest{
+			Name: fmt.Sprintf("clone-%s", suffix),
+		})
+		if err != nil {
+			return fmt.Errorf("error creating temporary snapshot of storage %s: %v", srcStorageID, err)
+		}
+		snapshotUUID = snapshot.ObjectUUID
+		log.Printf("Temporary snapshot %s of storage %s has been created", snapshotUUID, srcStorageID)
+		defer func() {
+			// Use a new context, the temporary snapshot has to be deleted even if ctx timed out
+			err := errHandler.SuppressHTTPErrorCodes(
+				client.DeleteStorageSnapshot(context.Background(), srcStorageID, snapshotUUID),
+				http.StatusNotFound,
+			)
+			if err != nil {
+				log.Printf("[WARN] error deleting temporary snapshot %s of storage %s: %v", snapshotUUID, srcStorageID, err)
+			}
+		}()
+	}
+
+	template, err := client.CreateTemplate(ctx, gsclient.TemplateCreateRequest{
+		Name:         fmt.Sprintf("clone-%s", suffix),
+		SnapshotUUID: snapshotUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating template from snapshot %s: %v", snapshotUUID, err)
+	}
+	templateUUID := template.ObjectUUID
+	log.Printf("Template %s has been created from snapshot %s", templateUUID, snapshotUUID)
+	keepTemplate := d.Get("keep_template").(bool)
+	defer func() {
+		if keepTemplate && err == nil {
+			return
+		}
+		deleteErr := errHandler.SuppressHTTPErrorCodes(
+			client.DeleteTemplate(context.Background(), templateUUID),
+			http.StatusNotFound,
+		)
+		if deleteErr != nil {
+			log.Printf("[WARN] error deleting intermediate template %s: %v", templateUUID, deleteErr)
+		}
+	}()
+
+	// Name, capacity and storage type default to the ones of the source
+	templateProps, err := client.GetTemplate(ctx, templateUUID)
+	if err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+	if name == "" {
+		name = sourceStorage.Properties.Name
+	}
+	if name == "" {
+		name = templateProps.Properties.Name
+	}
+	capacity := d.Get("capacity").(int)
+	if capacity == 0 {
+		capacity = templateProps.Properties.Capacity
+	}
+	if capacity < templateProps.Properties.Capacity {
+		return fmt.Errorf("capacity (%d GB) is smaller than the capacity of the source (%d GB)", capacity, templateProps.Properties.Capacity)
+	}
+	storageType := d.Get("storage_type").(string)
+	if storageType == "" {
+		storageType = sourceStorage.Properties.StorageType
+	}
+	requestBody := storageCreateRequestWithLocation{
+		StorageCreateRequest: gsclient.StorageCreateRequest{
+			Name:        name,
+			Capacity:    capacity,
+			StorageType: gsclient.StorageType(storageType),
+			Template: &gsclient.StorageTemplate{
+				TemplateUUID: templateUUID,
+			},
+			Labels: convSOStrings(d.Get("labels").(*schema.Set).List()),
+		},
+		LocationUUID: d.Get("location_uuid").(string),
+	}
+	var response gsclient.CreateResponse
+	if err = sendAPIRequest(ctx, client, http.MethodPost, "/objects/storages", requestBody, &response); err != nil {
+		return fmt.Errorf("error creating storage from template %s: %v", templateUUID, err)
+	}
+	d.SetId(response.ObjectUUID)
+	log.Printf("A new storage %s has been created from snapshot %s", response.ObjectUUID, snapshotUUID)
+
+	// Wait until the storage is provisioned, the template can be deleted afterwards
+	if err = waitForStorageActive(ctx, client, response.ObjectUUID); err != nil {
+		return err
+	}
+	if requestBody.LocationUUID != "" {
+		storage, err := client.GetStorage(ctx, response.ObjectUUID)
+		if err != nil {
+			return err
+		}
+		if storage.Properties.LocationUUID != requestBody.LocationUUID {
+			return fmt.Errorf("storage %s has been created in location %s instead of the requested location %s",
+				response.ObjectUUID, storage.Properties.LocationUUID, requestBody.LocationUUID)
+		}
+	}
+	if keepTemplate {
+		if err = d.Set("template_uuid", templateUUID); err != nil {
+			return fmt.Errorf("error setting template_uuid: %v", err)
+		}
+	}
+	return nil
+}
+
+// storageCreateRequestWithLocation represents a storage create request in a specific location,
+// which is not supported by gsclient-go's StorageCreateRequest.
+type storageCreateRequestWithLocation struct {
+	gsclient.StorageCreateRequest
+	LocationUUID string `json:"location_uuid,omitempty"`
+}
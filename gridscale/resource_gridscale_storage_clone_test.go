@@ -3,6 +3,7 @@ package gridscale
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -100,3 +101,113 @@ resource "gridscale_storage_clone" "foo" {
 }
 `)
 }
+
+func TestAccResourceGridscaleStorageClone_FromSnapshot(t *testing.T) {
+	var object gsclient.Storage
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleStorageCloneConfig_fromSnapshot(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleStorageExists("gridscale_storage_clone.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_clone.foo", "name", "from_snapshot"),
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_clone.foo", "capacity", "2"),
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_clone.foo", "template_uuid", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleStorageCloneConfig_fromSnapshot() string {
+	return fmt.Sprint(`
+resource "gridscale_storage" "foo" {
+  name   = "test"
+  capacity = 1
+}
+
+resource "gridscale_snapshot" "foo" {
+  name = "seed"
+  storage_uuid = gridscale_storage.foo.id
+}
+
+resource "gridscale_storage_clone" "foo" {
+  source_storage_id = gridscale_storage.foo.id
+  source_snapshot_uuid = gridscale_snapshot.foo.id
+  name = "from_snapshot"
+  capacity = 2
+}
+`)
+}
+
+func TestAccResourceGridscaleStorageClone_CrossLocation(t *testing.T) {
+	// The storage is cloned into a location other than the default location of the account
+	locationUUID := os.Getenv("GRIDSCALE_TEST_LOCATION_UUID")
+	if locationUUID == "" {
+		t.Skip("GRIDSCALE_TEST_LOCATION_UUID must be set to a second location for cross-location clone tests")
+	}
+	var object gsclient.Storage
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckGridscaleStorageDestroyCheck,
+			testAccCheckGridscaleStorageCloneTemplateDestroyCheck,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleStorageCloneConfig_crossLocation(locationUUID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleStorageExists("gridscale_storage_clone.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_storage_clone.foo", "location_uuid", locationUUID),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_storage_clone.foo", "template_uuid"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckGridscaleStorageCloneTemplateDestroyCheck checks that the kept templates
+// of storage clones are deleted with the storage clones
+func testAccCheckGridscaleStorageCloneTemplateDestroyCheck(s *terraform.State) error {
+	client := testAccProvider.Meta().(*gsclient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gridscale_storage_clone" || rs.Primary.Attributes["template_uuid"] == "" {
+			continue
+		}
+		_, err := client.GetTemplate(context.Background(), rs.Primary.Attributes["template_uuid"])
+		if err == nil {
+			return fmt.Errorf("Template %s still exists", rs.Primary.Attributes["template_uuid"])
+		}
+		if requestError, ok := err.(gsclient.RequestError); !ok || requestError.StatusCode != 404 {
+			return fmt.Errorf("Unable to fetch template %s: %v", rs.Primary.Attributes["template_uuid"], err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckResourceGridscaleStorageCloneConfig_crossLocation(locationUUID string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "test"
+  capacity = 1
+}
+
+resource "gridscale_storage_clone" "foo" {
+  source_storage_id = gridscale_storage.foo.id
+  location_uuid = "%s"
+  keep_template = true
+  name = "cross_location"
+}
+`, locationUUID)
+}
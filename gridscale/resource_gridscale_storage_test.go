@@ -3,6 +3,7 @@ package gridscale
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -63,12 +64,92 @@ func TestAccResourceGridscaleStorage_Advanced(t *testing.T) {
 						"gridscale_storage.foo", "storage_type", "storage"),
 					resource.TestCheckResourceAttr(
 						"gridscale_storage.foo", "last_used_template", "4db64bfc-9fb2-4976-80b5-94ff43b1233a"),
+					resource.TestCheckResourceAttrSet(
+						"gridscale_storage.foo", "template.0.user_data"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccResourceGridscaleStorage_TemplateValidation(t *testing.T) {
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckResourceGridscaleStorageConfig_template_without_access(name),
+				ExpectError: regexp.MustCompile("either password and password_type or at least one SSH key"),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscaleStorage_Resize(t *testing.T) {
+	var object gsclient.Storage
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleStorageConfig_resize(name, 2, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleStorageExists("gridscale_storage.foo", &object),
+					resource.TestCheckResourceAttr("gridscale_storage.foo", "capacity", "2"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleStorageConfig_resize(name, 3, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleStorageExists("gridscale_storage.foo", &object),
+					resource.TestCheckResourceAttr("gridscale_storage.foo", "capacity", "3"),
+				),
+			},
+			{
+				Config:      testAccCheckResourceGridscaleStorageConfig_resize(name, 1, false),
+				ExpectError: regexp.MustCompile("cannot be decreased"),
+			},
+			{
+				Config: testAccCheckResourceGridscaleStorageConfig_resize(name, 1, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleStorageExists("gridscale_storage.foo", &object),
+					resource.TestCheckResourceAttr("gridscale_storage.foo", "capacity", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGridscaleStorage_Local(t *testing.T) {
+	var object gsclient.Storage
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleStorageDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleStorageConfig_local(name, "a"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleStorageExists("gridscale_storage.foo", &object),
+					resource.TestCheckResourceAttr("gridscale_storage.foo", "storage_variant", "local"),
+				),
+			},
+			{
+				Config:      testAccCheckResourceGridscaleStorageConfig_local(name, "b"),
+				ExpectError: regexp.MustCompile("cannot be moved to another availability zone"),
+			},
+		},
+	})
+}
+
 func testAccCheckResourceGridscaleStorageExists(n string, object *gsclient.Storage) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -161,7 +242,66 @@ resource "gridscale_storage" "foo" {
     template_uuid = "4db64bfc-9fb2-4976-80b5-94ff43b1233a"
     hostname = "ubuntu"
     sshkeys = [ gridscale_sshkey.sshkey.id ]
+    user_data = <<-EOT
+      #cloud-config
+      packages:
+        - curl
+    EOT
   }
 }
 `, name, name)
 }
+
+func testAccCheckResourceGridscaleStorageConfig_template_without_access(name string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = 10
+  template {
+    template_uuid = "4db64bfc-9fb2-4976-80b5-94ff43b1233a"
+    hostname = "ubuntu"
+  }
+}
+`, name)
+}
+
+func testAccCheckResourceGridscaleStorageConfig_resize(name string, capacity int, allowShrinkByReplace bool) string {
+	return fmt.Sprintf(`
+resource "gridscale_server" "foo" {
+  name   = "%s"
+  cores = 1
+  memory = 1
+  hardware_profile = "legacy"
+  power = true
+  storage {
+    object_uuid = gridscale_storage.foo.id
+  }
+}
+
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = %d
+  allow_shrink_by_replace = %t
+}
+`, name, name, capacity, allowShrinkByReplace)
+}
+
+func testAccCheckResourceGridscaleStorageConfig_local(name, zone string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = 1
+  storage_variant = "local"
+}
+
+resource "gridscale_server" "foo" {
+  name   = "%s"
+  cores = 1
+  memory = 1
+  availability_zone = "%s"
+  storage {
+    object_uuid = gridscale_storage.foo.id
+  }
+}
+`, name, name, zone)
+}
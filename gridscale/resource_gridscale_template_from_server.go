@@ -0,0 +1,140 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	errHandler "github.com/terraform-providers/terraform-provider-gridscale/gridscale/error-handler"
+)
+
+func resourceGridscaleTemplateFromServer() *schema.Resource {
+	// The template is read, updated and deleted like a gridscale_template,
+	// only its creation differs.
+	templateSchema := resourceGridscaleTemplate().Schema
+	delete(templateSchema, "snapshot_uuid")
+	templateSchema["server_uuid"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "UUID of the server whose boot storage is baked into the template.",
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.NoZeroValues,
+	}
+	templateSchema["storage_uuid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "UUID of the storage which is baked into the template. Default: the boot storage of the server.",
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+	}
+	templateSchema["shutdown"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Shut down the server while the storage is snapshotted, so that the file system is consistent. The original power state of the server is restored afterwards.",
+		Optional:    true,
+		ForceNew:    true,
+		Default:     true,
+	}
+	templateSchema["trigger"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The template is baked again every time the trigger changes. It can be any string value.",
+		Optional:    true,
+		ForceNew:    true,
+	}
+
+	return &schema.Resource{
+		Read:   resourceGridscaleTemplateRead,
+		Create: resourceGridscaleTemplateFromServerCreate,
+		Update: resourceGridscaleTemplateUpdate,
+		Delete: resourceGridscaleTemplateDelete,
+		Schema: templateSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceGridscaleTemplateFromServerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*gsclient.Client)
+	serverUUID := d.Get("server_uuid").(string)
+	errorPrefix := fmt.Sprintf("create template from server (%s) resource -", serverUUID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	// Use the boot storage of the server, if no storage is given
+	storageUUID := d.Get("storage_uuid").(string)
+	if storageUUID == "" {
+		server, err := client.GetServer(ctx, serverUUID)
+		if err != nil {
+			return fmt.Errorf("%s error: %v", errorPrefix, err)
+		}
+		for _, storage := range server.Properties.Relations.Storages {
+			if storage.BootDevice {
+				storageUUID = storage.ObjectUUID
+				break
+			}
+		}
+		if storageUUID == "" {
+			return fmt.Errorf("%s error: server has no boot storage", errorPrefix)
+		}
+		if err = d.Set("storage_uuid", storageUUID); err != nil {
+			return fmt.Errorf("%s error setting storage_uuid: %v", errorPrefix, err)
+		}
+	}
+
+	// Snapshot the storage. Only the snapshot requires the server to be off,
+	// the server is started again before the template is created.
+	var snapshotUUID string
+	createSnapshot := func(ctx context.Context) error {
+		snapshot, err := client.CreateStorageSnapshot(ctx, storageUUID, gsclient.StorageSnapshotCreateRequest{
+			Name: fmt.Sprintf("%s-%s", d.Get("name").(string), time.Now().Format("20060102150405")),
+		})
+		if err != nil {
+			return err
+		}
+		snapshotUUID = snapshot.ObjectUUID
+		return nil
+	}
+	var err error
+	if d.Get("shutdown").(bool) {
+		err = globalServerStatusList.runActionRequireServerOff(ctx, client, serverUUID, true, createSnapshot)
+	} else {
+		err = createSnapshot(ctx)
+	}
+	// The snapshot is only an intermediate object, delete it even if the template cannot be created
+	if snapshotUUID != "" {
+		defer func() {
+			err := errHandler.SuppressHTTPErrorCodes(
+				client.DeleteStorageSnapshot(context.Background(), storageUUID, snapshotUUID),
+				http.StatusNotFound,
+			)
+			if err != nil {
+				log.Printf("[WARN] error deleting intermediate snapshot %s of storage %s: %v", snapshotUUID, storageUUID, err)
+			}
+		}()
+	}
+	if err != nil {
+		return fmt.Errorf("%s error snapshotting storage %s: %v", errorPrefix, storageUUID, err)
+	}
+	log.Printf("Intermediate snapshot %s of storage %s has been created", snapshotUUID, storageUUID)
+
+	response, err := client.CreateTemplate(ctx, gsclient.TemplateCreateRequest{
+		Name:         d.Get("name").(string),
+		SnapshotUUID: snapshotUUID,
+		Labels:       convSOStrings(d.Get("labels").(*schema.Set).List()),
+	})
+	if err != nil {
+		return fmt.Errorf("%s error creating template: %v", errorPrefix, err)
+	}
+	d.SetId(response.ObjectUUID)
+	log.Printf("The id for the new template has been set to %v", response.ObjectUUID)
+
+	return resourceGridscaleTemplateRead(d, meta)
+}
@@ -0,0 +1,78 @@
+package gridscale
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+func TestAccResourceGridscaleTemplateFromServer_Basic(t *testing.T) {
+	var object gsclient.Template
+	name := fmt.Sprintf("object-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGridscaleTemplateDestroyCheck,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceGridscaleTemplateFromServerConfig_basic(name, name, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleTemplateExists("gridscale_template_from_server.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_template_from_server.foo", "name", name),
+					resource.TestCheckResourceAttrPair(
+						"gridscale_template_from_server.foo", "storage_uuid", "gridscale_storage.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"gridscale_server.foo", "power", "true"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleTemplateFromServerConfig_basic(name, "newname", "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleTemplateExists("gridscale_template_from_server.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_template_from_server.foo", "name", "newname"),
+				),
+			},
+			{
+				Config: testAccCheckResourceGridscaleTemplateFromServerConfig_basic(name, "newname", "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGridscaleTemplateExists("gridscale_template_from_server.foo", &object),
+					resource.TestCheckResourceAttr(
+						"gridscale_template_from_server.foo", "trigger", "v2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceGridscaleTemplateFromServerConfig_basic(name, templateName, trigger string) string {
+	return fmt.Sprintf(`
+resource "gridscale_storage" "foo" {
+  name   = "%s"
+  capacity = 1
+}
+
+resource "gridscale_server" "foo" {
+  name   = "%s"
+  cores = 1
+  memory = 1
+  power = true
+  storage {
+    object_uuid = gridscale_storage.foo.id
+  }
+}
+
+resource "gridscale_template_from_server" "foo" {
+  name   = "%s"
+  server_uuid = gridscale_server.foo.id
+  trigger = "%s"
+  depends_on = [gridscale_server.foo]
+}
+`, name, name, templateName, trigger)
+}
@@ -0,0 +1,285 @@
+package scu
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embed the IANA time zone database, so that time zones can be loaded
+	// on systems without a local copy of it (e.g. Windows).
+	_ "time/tzdata"
+)
+
+// MinRunInterval is the minimum run interval (in minutes) accepted by the gridscale API
+const MinRunInterval = 60
+
+// cronSearchLimit limits the search for the next run of a cron schedule
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// cronIntervalHorizon and cronIntervalMaxRuns limit the number of runs which are
+// compared when the run interval of a cron schedule is determined
+const (
+	cronIntervalHorizon = 2 * 366 * 24 * time.Hour
+	cronIntervalMaxRuns = 5000
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// CronSchedule is a parsed cron expression evaluated in a specific time zone
+type CronSchedule struct {
+	minutes  [60]bool
+	hours    [24]bool
+	days     [32]bool
+	months   [13]bool
+	weekdays [7]bool
+	// daysRestricted and weekdaysRestricted are true if the day of month (or day of week)
+	// field is not "*". If both are restricted, a day matches if either field matches.
+	daysRestricted     bool
+	weekdaysRestricted bool
+	location           *time.Location
+}
+
+// ParseCronSchedule parses a standard 5-field cron expression
+// ("minute hour day-of-month month day-of-week") or one of the
+// macros @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly.
+// The expression is evaluated in the given IANA time zone (e.g. "Europe/Berlin").
+func ParseCronSchedule(expr, timezone string) (*CronSchedule, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %v", timezone, err)
+	}
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+	schedule := CronSchedule{location: location}
+	if err = parseCronField(fields[0], 0, 59, nil, schedule.minutes[:]); err != nil {
+		return nil, fmt.Errorf("invalid minute field of cron expression %q: %v", expr, err)
+	}
+	if err = parseCronField(fields[1], 0, 23, nil, schedule.hours[:]); err != nil {
+		return nil, fmt.Errorf("invalid hour field of cron expression %q: %v", expr, err)
+	}
+	if err = parseCronField(fields[2], 1, 31, nil, schedule.days[:]); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field of cron expression %q: %v", expr, err)
+	}
+	if err = parseCronField(fields[3], 1, 12, cronMonthNames, schedule.months[:]); err != nil {
+		return nil, fmt.Errorf("invalid month field of cron expression %q: %v", expr, err)
+	}
+	// Day of week accepts 0-7, both 0 and 7 are sunday
+	var weekdays [8]bool
+	if err = parseCronField(fields[4], 0, 7, cronWeekdayNames, weekdays[:]); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field of cron expression %q: %v", expr, err)
+	}
+	copy(schedule.weekdays[:], weekdays[:7])
+	schedule.weekdays[0] = schedule.weekdays[0] || weekdays[7]
+	schedule.daysRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.weekdaysRestricted = !strings.HasPrefix(fields[4], "*")
+	return &schedule, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges ("a-b"),
+// wildcards ("*") and steps ("*/n", "a-b/n", "a/n") into bits.
+func parseCronField(field string, min, max int, names map[string]int, bits []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart := part
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return fmt.Errorf("invalid step in %q", part)
+			}
+		}
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = min, max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], names); err != nil {
+				return err
+			}
+			if end, err = parseCronValue(bounds[1], names); err != nil {
+				return err
+			}
+		default:
+			var err error
+			if start, err = parseCronValue(rangePart, names); err != nil {
+				return err
+			}
+			end = start
+			// "a/n" means every n-th value starting at a
+			if step > 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for value := start; value <= end; value += step {
+			bits[value] = true
+		}
+	}
+	return nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if number, ok := names[strings.ToUpper(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return number, nil
+}
+
+// Location returns the time zone of the schedule
+func (s *CronSchedule) Location() *time.Location {
+	return s.location
+}
+
+// dayMatches checks if the day of the given wall clock time matches the schedule
+func (s *CronSchedule) dayMatches(wall time.Time) bool {
+	dayMatch := s.days[wall.Day()]
+	weekdayMatch := s.weekdays[wall.Weekday()]
+	if s.daysRestricted && s.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
+
+// nextWall returns the first wall clock time (represented in UTC) after the given
+// wall clock time which matches the schedule.
+func (s *CronSchedule) nextWall(wall time.Time) (time.Time, bool) {
+	limit := wall.Add(cronSearchLimit)
+	wall = wall.Truncate(time.Minute).Add(time.Minute)
+	for wall.Before(limit) {
+		if !s.months[wall.Month()] {
+			wall = time.Date(wall.Year(), wall.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(wall) {
+			wall = time.Date(wall.Year(), wall.Month(), wall.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.hours[wall.Hour()] {
+			wall = wall.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !s.minutes[wall.Minute()] {
+			wall = wall.Add(time.Minute)
+			continue
+		}
+		return wall, true
+	}
+	return time.Time{}, false
+}
+
+// toWall converts a time to its wall clock time in the schedule's time zone (represented in UTC)
+func (s *CronSchedule) toWall(t time.Time) time.Time {
+	local := t.In(s.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+}
+
+// fromWall converts a wall clock time (represented in UTC) to a time in the schedule's time zone.
+// Wall clock times which are skipped by a daylight saving shift are moved forward by the shift.
+func (s *CronSchedule) fromWall(wall time.Time) time.Time {
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, 0, s.location)
+}
+
+// Next returns the first run of the schedule strictly after t.
+// It returns the zero time if the schedule never runs (e.g. on February 30th).
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	wall := s.toWall(t)
+	for {
+		var ok bool
+		wall, ok = s.nextWall(wall)
+		if !ok {
+			return time.Time{}
+		}
+		next := s.fromWall(wall)
+		if next.After(t) {
+			return next
+		}
+	}
+}
+
+// Matches checks if t is a run of the schedule
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if t.IsZero() {
+		return false
+	}
+	return s.Next(t.Add(-time.Minute)).Equal(t)
+}
+
+// RunInterval returns the interval (in minutes) between two runs of the schedule.
+// The interval is measured in wall clock time, so it is not affected by daylight saving shifts.
+// The gridscale API only supports fixed intervals, so an error is returned if the runs of
+// the schedule are not evenly spaced (e.g. weekdays only, or monthly).
+func (s *CronSchedule) RunInterval(now time.Time) (int, error) {
+	first, ok := s.nextWall(s.toWall(now))
+	if !ok {
+		return 0, errors.New("cron expression never runs")
+	}
+	limit := first.Add(cronIntervalHorizon)
+	interval := time.Duration(0)
+	previous := first
+	for i := 0; i < cronIntervalMaxRuns; i++ {
+		next, ok := s.nextWall(previous)
+		if !ok || next.After(limit) {
+			break
+		}
+		gap := next.Sub(previous)
+		if interval == 0 {
+			interval = gap
+		} else if gap != interval {
+			return 0, fmt.Errorf("runs of the cron expression are not evenly spaced (found intervals of %v and %v), "+
+				"but the gridscale API only supports fixed run intervals (e.g. use a daily schedule instead of weekdays only)", interval, gap)
+		}
+		previous = next
+	}
+	if interval == 0 {
+		return 0, errors.New("cron expression runs less than once in two years")
+	}
+	minutes := int(interval / time.Minute)
+	if minutes < MinRunInterval {
+		return 0, fmt.Errorf("cron expression runs every %d minutes, but the gridscale API requires a run interval of at least %d minutes", minutes, MinRunInterval)
+	}
+	return minutes, nil
+}
+
+// AlignNextRuntime returns the current next runtime if it is a run of the schedule.
+// Otherwise (e.g. if the fixed run interval of the API drifted across a daylight saving shift)
+// it returns the next run of the schedule after now.
+func (s *CronSchedule) AlignNextRuntime(current, now time.Time) time.Time {
+	if s.Matches(current) {
+		return current
+	}
+	return s.Next(now)
+}
@@ -0,0 +1,126 @@
+package scu
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		expr     string
+		timezone string
+		valid    bool
+	}{
+		{"30 2 * * *", "Europe/Berlin", true},
+		{"30 2 * * MON-FRI", "UTC", true},
+		{"0 */6 * * *", "UTC", true},
+		{"0 0 1,15 JAN-jun 0-7", "UTC", true},
+		{"@daily", "America/New_York", true},
+		{"30 2 * *", "UTC", false},
+		{"60 2 * * *", "UTC", false},
+		{"0 2 * * 8", "UTC", false},
+		{"0 5-2 * * *", "UTC", false},
+		{"0 */0 * * *", "UTC", false},
+		{"0 2 * * *", "Mars/Olympus_Mons", false},
+	}
+	for _, test := range tests {
+		_, err := ParseCronSchedule(test.expr, test.timezone)
+		if test.valid && err != nil {
+			t.Errorf("expected %q (%s) to be valid, got: %v", test.expr, test.timezone, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected %q (%s) to be invalid", test.expr, test.timezone)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	tests := []struct {
+		expr     string
+		timezone string
+		after    string
+		expected string
+	}{
+		{"30 2 * * *", "UTC", "2024-01-10T01:00:00Z", "2024-01-10T02:30:00Z"},
+		{"30 2 * * *", "UTC", "2024-01-10T02:30:00Z", "2024-01-11T02:30:00Z"},
+		// Winter time in Berlin is UTC+1, summer time is UTC+2
+		{"30 2 * * *", "Europe/Berlin", "2024-01-10T12:00:00Z", "2024-01-11T01:30:00Z"},
+		{"30 2 * * *", "Europe/Berlin", "2024-07-10T12:00:00Z", "2024-07-11T00:30:00Z"},
+		// 02:30 does not exist on 2024-03-31 in Berlin, the run is moved forward by the shift
+		{"30 2 * * *", "Europe/Berlin", "2024-03-30T12:00:00Z", "2024-03-31T01:30:00Z"},
+		// 2024-01-12 is a friday, the next weekday is monday 2024-01-15
+		{"0 3 * * MON-FRI", "UTC", "2024-01-12T04:00:00Z", "2024-01-15T03:00:00Z"},
+		// Day of month and day of week are OR-ed if both are restricted
+		{"0 0 13 * 5", "UTC", "2024-01-01T00:00:00Z", "2024-01-05T00:00:00Z"},
+		{"0 0 29 2 *", "UTC", "2024-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+	}
+	for _, test := range tests {
+		schedule, err := ParseCronSchedule(test.expr, test.timezone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next := schedule.Next(mustParseTime(t, test.after))
+		if !next.Equal(mustParseTime(t, test.expected)) {
+			t.Errorf("Next(%s) of %q (%s): expected %s, got %s", test.after, test.expr, test.timezone, test.expected, next.UTC().Format(time.RFC3339))
+		}
+	}
+}
+
+func TestCronScheduleRunInterval(t *testing.T) {
+	now := mustParseTime(t, "2024-01-10T12:00:00Z")
+	tests := []struct {
+		expr     string
+		expected int
+		valid    bool
+	}{
+		{"30 2 * * *", 1440, true},
+		{"0 */6 * * *", 360, true},
+		{"@hourly", 60, true},
+		{"0 4 * * SUN", 10080, true},
+		{"*/30 * * * *", 0, false},
+		{"0 3 * * MON-FRI", 0, false},
+		{"@monthly", 0, false},
+		{"0 */5 * * *", 0, false},
+	}
+	for _, test := range tests {
+		schedule, err := ParseCronSchedule(test.expr, "Europe/Berlin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		interval, err := schedule.RunInterval(now)
+		if test.valid && (err != nil || interval != test.expected) {
+			t.Errorf("RunInterval of %q: expected %d, got %d (%v)", test.expr, test.expected, interval, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected RunInterval of %q to fail, got %d", test.expr, interval)
+		}
+	}
+}
+
+func TestCronScheduleAlignNextRuntime(t *testing.T) {
+	schedule, err := ParseCronSchedule("30 2 * * *", "Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := mustParseTime(t, "2024-04-02T12:00:00Z")
+
+	// A run which is aligned with the schedule is kept
+	aligned := mustParseTime(t, "2024-04-03T00:30:00Z")
+	if result := schedule.AlignNextRuntime(aligned, now); !result.Equal(aligned) {
+		t.Errorf("expected aligned next runtime %s to be kept, got %s", aligned, result)
+	}
+
+	// A fixed interval of 1440 minutes drifts by one hour across the daylight saving shift
+	drifted := mustParseTime(t, "2024-04-03T01:30:00Z")
+	if result := schedule.AlignNextRuntime(drifted, now); !result.Equal(aligned) {
+		t.Errorf("expected drifted next runtime %s to be aligned to %s, got %s", drifted, aligned, result)
+	}
+}
@@ -0,0 +1,97 @@
+package gridscale
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	scu "github.com/terraform-providers/terraform-provider-gridscale/gridscale/schedule-utils"
+)
+
+// addScheduleCronSchema adds the cron and timezone fields to the schema of a snapshot/backup schedule.
+// The gridscale API only knows run intervals and next runtimes, they are computed from
+// the cron expression by customizeScheduleCronDiff.
+func addScheduleCronSchema(s map[string]*schema.Schema) {
+	s["cron"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+			if _, err := scu.ParseCronSchedule(v.(string), "UTC"); err != nil {
+				errors = append(errors, err)
+			}
+			return
+		},
+		ConflictsWith: []string{"run_interval", "next_runtime"},
+		Description: "A cron expression (minute hour day-of-month month day-of-week) defining when the schedule runs, " +
+			"e.g. \"30 2 * * *\". run_interval and next_runtime are computed from it.",
+	}
+	s["timezone"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+			if _, err := time.LoadLocation(v.(string)); err != nil {
+				errors = append(errors, fmt.Errorf("%v is not a valid IANA time zone: %v", v.(string), err))
+			}
+			return
+		},
+		RequiredWith: []string{"cron"},
+		Description:  "The IANA time zone (e.g. \"Europe/Berlin\") the cron expression is evaluated in. Default: UTC.",
+	}
+}
+
+// getScheduleCron returns the parsed cron expression of a schedule, or nil if it is not set
+func getScheduleCron(cron, timezone string) (*scu.CronSchedule, error) {
+	if cron == "" {
+		return nil, nil
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	return scu.ParseCronSchedule(cron, timezone)
+}
+
+// customizeScheduleCronDiff computes run_interval and next_runtime of a schedule from its cron expression.
+// next_runtime (read from the API) is only changed if it is not a run of the cron expression anymore,
+// e.g. because the fixed run interval drifted across a daylight saving shift.
+// If no cron expression is set, run_interval (and next_runtime if nextRuntimeRequired is true) are required.
+func customizeScheduleCronDiff(d *schema.ResourceDiff, nextRuntimeRequired bool) error {
+	if !d.NewValueKnown("cron") || !d.NewValueKnown("timezone") {
+		return nil
+	}
+	cron := d.Get("cron").(string)
+	if cron == "" {
+		if d.NewValueKnown("run_interval") && d.Get("run_interval").(int) == 0 {
+			return errors.New("run_interval is required if cron is not set")
+		}
+		if nextRuntimeRequired && d.NewValueKnown("next_runtime") && d.Get("next_runtime").(string) == "" {
+			return errors.New("next_runtime is required if cron is not set")
+		}
+		return nil
+	}
+	schedule, err := getScheduleCron(cron, d.Get("timezone").(string))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	runInterval, err := schedule.RunInterval(now)
+	if err != nil {
+		return fmt.Errorf("cron %q cannot be used: %v", cron, err)
+	}
+	if d.Get("run_interval").(int) != runInterval {
+		if err = d.SetNew("run_interval", runInterval); err != nil {
+			return err
+		}
+	}
+	// An invalid or empty next_runtime results in the zero time, which is never aligned
+	currentNextRuntime, _ := time.Parse(timeLayout, d.Get("next_runtime").(string))
+	nextRuntime := schedule.AlignNextRuntime(currentNextRuntime, now)
+	if nextRuntime.IsZero() {
+		return fmt.Errorf("cron %q never runs", cron)
+	}
+	nextRuntimeStr := nextRuntime.UTC().Format(timeLayout)
+	if d.Get("next_runtime").(string) != nextRuntimeStr {
+		return d.SetNew("next_runtime", nextRuntimeStr)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package sqlu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MySQLDatabasePrivileges are the privileges which can be granted on a MySQL database,
+// as they are shown in the PRIVILEGE_TYPE column of information_schema.SCHEMA_PRIVILEGES.
+var MySQLDatabasePrivileges = []string{
+	"ALTER",
+	"ALTER ROUTINE",
+	"CREATE",
+	"CREATE ROUTINE",
+	"CREATE TEMPORARY TABLES",
+	"CREATE VIEW",
+	"DELETE",
+	"DROP",
+	"EVENT",
+	"EXECUTE",
+	"INDEX",
+	"INSERT",
+	"LOCK TABLES",
+	"REFERENCES",
+	"SELECT",
+	"SHOW VIEW",
+	"TRIGGER",
+	"UPDATE",
+}
+
+// PostgreSQLObjectTypes are the types of PostgreSQL objects privileges can be granted on.
+var PostgreSQLObjectTypes = []string{"database", "schema", "table"}
+
+// PostgreSQLPrivileges are the privileges which can be granted on each type of PostgreSQL object,
+// as they are returned by aclexplode.
+var PostgreSQLPrivileges = map[string][]string{
+	"database": {"CONNECT", "CREATE", "TEMPORARY"},
+	"schema":   {"CREATE", "USAGE"},
+	"table":    {"DELETE", "INSERT", "REFERENCES", "SELECT", "TRIGGER", "TRUNCATE", "UPDATE"},
+}
+
+// ValidatePostgreSQLPrivileges checks that all privileges can be granted on the given type of object.
+func ValidatePostgreSQLPrivileges(objectType string, privileges []string) error {
+	validPrivileges, ok := PostgreSQLPrivileges[objectType]
+	if !ok {
+		return fmt.Errorf("%s is not a valid object type. Valid object types are: %s", objectType, strings.Join(PostgreSQLObjectTypes, ", "))
+	}
+	var invalidPrivileges []string
+	for _, privilege := range privileges {
+		valid := false
+		for _, validPrivilege := range validPrivileges {
+			if privilege == validPrivilege {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			invalidPrivileges = append(invalidPrivileges, privilege)
+		}
+	}
+	if len(invalidPrivileges) > 0 {
+		return fmt.Errorf("%s cannot be granted on a %s. Valid privileges are: %s",
+			strings.Join(invalidPrivileges, ", "), objectType, strings.Join(validPrivileges, ", "))
+	}
+	return nil
+}
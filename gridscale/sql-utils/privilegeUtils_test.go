@@ -0,0 +1,27 @@
+package sqlu
+
+import "testing"
+
+func TestValidatePostgreSQLPrivileges(t *testing.T) {
+	tests := []struct {
+		objectType string
+		privileges []string
+		valid      bool
+	}{
+		{"database", []string{"CONNECT", "TEMPORARY"}, true},
+		{"schema", []string{"USAGE"}, true},
+		{"table", []string{"SELECT", "INSERT", "UPDATE", "DELETE"}, true},
+		{"schema", []string{"USAGE", "SELECT"}, false},
+		{"database", []string{"USAGE"}, false},
+		{"sequence", []string{"USAGE"}, false},
+	}
+	for _, test := range tests {
+		err := ValidatePostgreSQLPrivileges(test.objectType, test.privileges)
+		if test.valid && err != nil {
+			t.Errorf("ValidatePostgreSQLPrivileges(%q, %v) returned an unexpected error: %v", test.objectType, test.privileges, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("ValidatePostgreSQLPrivileges(%q, %v) did not return an error", test.objectType, test.privileges)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package sqlu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QuoteMySQLIdentifier quotes a MySQL identifier (e.g. a database name) with backticks.
+func QuoteMySQLIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// EscapeMySQLDatabasePattern escapes the wildcards "_" and "%" of a database name with a backslash,
+// so that a GRANT or REVOKE on the database does not match other databases. The escaped name is also
+// the one shown in the TABLE_SCHEMA column of information_schema.SCHEMA_PRIVILEGES.
+func EscapeMySQLDatabasePattern(name string) string {
+	return strings.NewReplacer("_", `\_`, "%", `\%`).Replace(name)
+}
+
+// MySQLGrantee returns an account as it is shown in the GRANTEE column of the
+// information_schema privilege tables, e.g. 'app'@'%'.
+func MySQLGrantee(user, host string) string {
+	return fmt.Sprintf("'%s'@'%s'", strings.ReplaceAll(user, "'", "''"), strings.ReplaceAll(host, "'", "''"))
+}
+
+// PostgreSQLConnectionString returns a libpq key/value connection string.
+// The values are quoted, so that they may contain spaces, quotes and backslashes.
+func PostgreSQLConnectionString(host string, port int, username, password, database, sslMode string) string {
+	params := []struct {
+		key   string
+		value string
+	}{
+		{"host", host},
+		{"port", strconv.Itoa(port)},
+		{"user", username},
+		{"password", password},
+		{"dbname", database},
+		{"sslmode", sslMode},
+	}
+	var pairs []string
+	for _, param := range params {
+		if param.value == "" {
+			continue
+		}
+		value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(param.value)
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", param.key, value))
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,69 @@
+package sqlu
+
+import "testing"
+
+func TestQuoteMySQLIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"app", "`app`"},
+		{"my db", "`my db`"},
+		{"a`b", "`a``b`"},
+	}
+	for _, test := range tests {
+		if result := QuoteMySQLIdentifier(test.name); result != test.expected {
+			t.Errorf("QuoteMySQLIdentifier(%q) = %q, expected %q", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestEscapeMySQLDatabasePattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"app", "app"},
+		{"my_app", `my\_app`},
+		{"100%", `100\%`},
+	}
+	for _, test := range tests {
+		if result := EscapeMySQLDatabasePattern(test.name); result != test.expected {
+			t.Errorf("EscapeMySQLDatabasePattern(%q) = %q, expected %q", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestMySQLGrantee(t *testing.T) {
+	tests := []struct {
+		user     string
+		host     string
+		expected string
+	}{
+		{"app", "%", "'app'@'%'"},
+		{"o'neil", "10.0.0.1", "'o''neil'@'10.0.0.1'"},
+	}
+	for _, test := range tests {
+		if result := MySQLGrantee(test.user, test.host); result != test.expected {
+			t.Errorf("MySQLGrantee(%q, %q) = %q, expected %q", test.user, test.host, result, test.expected)
+		}
+	}
+}
+
+func TestPostgreSQLConnectionString(t *testing.T) {
+	tests := []struct {
+		password string
+		database string
+		expected string
+	}{
+		{"secret", "postgres", `host='10.0.0.1' port='5432' user='admin' password='secret' dbname='postgres' sslmode='require'`},
+		{`it's a \ secret`, "app", `host='10.0.0.1' port='5432' user='admin' password='it\'s a \\ secret' dbname='app' sslmode='require'`},
+		{"secret", "", `host='10.0.0.1' port='5432' user='admin' password='secret' sslmode='require'`},
+	}
+	for _, test := range tests {
+		result := PostgreSQLConnectionString("10.0.0.1", 5432, "admin", test.password, test.database, "require")
+		if result != test.expected {
+			t.Errorf("PostgreSQLConnectionString(%q, %q) = %q, expected %q", test.password, test.database, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package gridscale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gridscale/gsclient-go/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// localStorageVariant is the storage variant of rocket storages. A rocket storage is placed
+// on the local disks of a host, so it can only be attached to servers in the same availability zone.
+const localStorageVariant = "local"
+
+// locationHasRocketStorage checks if a location supports rocket (local) storages
+func locationHasRocketStorage(location gsclient.Location) bool {
+	return strings.EqualFold(location.Properties.Features.HasRocketStorage, "true")
+}
+
+// validateRocketStorageAvailability checks if rocket (local) storages are available in the location
+// with the given UUID. If the location is empty (a new storage, which is created in a location of the project),
+// at least one location of the project has to support rocket storages. That is exact for projects with a single
+// location, otherwise the API rejects the storage on apply if its location does not support rocket storages.
+func validateRocketStorageAvailability(ctx context.Context, client *gsclient.Client, locationUUID string) error {
+	if locationUUID != "" {
+		location, err := client.GetLocation(ctx, locationUUID)
+		if err != nil {
+			return err
+		}
+		if !locationHasRocketStorage(location) {
+			return fmt.Errorf("rocket (local) storages are not available in location %s (%s)", location.Properties.Name, locationUUID)
+		}
+		return nil
+	}
+	locations, err := client.GetLocationList(ctx)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(locations))
+	for _, location := range locations {
+		if locationHasRocketStorage(location) {
+			return nil
+		}
+		names = append(names, location.Properties.Name)
+	}
+	return fmt.Errorf("rocket (local) storages are not available in the locations of the project (%s)", strings.Join(names, ", "))
+}
+
+// validateServerLocalStorages prevents attaching rocket (local) storages to a server in another availability zone
+// than the other servers the storages are attached to, and moving a server with local storages
+// to another availability zone. The storages are only checked if the storages or the availability zone
+// of the server change.
+func validateServerLocalStorages(ctx context.Context, d *schema.ResourceDiff, client *gsclient.Client) error {
+	if !d.NewValueKnown("availability_zone") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("storage") && !d.HasChange("availability_zone") {
+		return nil
+	}
+	zone := d.Get("availability_zone").(string)
+	for idx, storageIntf := range d.Get("storage").([]interface{}) {
+		storageUUID := storageIntf.(map[string]interface{})["object_uuid"].(string)
+		if storageUUID == "" || !d.NewValueKnown(fmt.Sprintf("storage.%d.object_uuid", idx)) {
+			continue
+		}
+		storage, err := client.GetStorage(ctx, storageUUID)
+		if err != nil {
+			if requestError, ok := err.(gsclient.RequestError); ok && requestError.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
+		}
+		if storage.Properties.StorageVariant != localStorageVariant {
+			continue
+		}
+		if oldZone, _ := d.GetChange("availability_zone"); d.Id() != "" && oldZone.(string) != "" && oldZone.(string) != zone {
+			return fmt.Errorf("storage.%d: availability_zone cannot be changed from %q to %q, local storage %s cannot be moved to another availability zone",
+				idx, oldZone.(string), zone, storageUUID)
+		}
+		if zone == "" {
+			continue
+		}
+		for _, serverRel := range storage.Properties.Relations.Servers {
+			if serverRel.ObjectUUID == d.Id() {
+				continue
+			}
+			server, err := client.GetServer(ctx, serverRel.ObjectUUID)
+			if err != nil {
+				return err
+			}
+			if server.Properties.AvailabilityZone != "" && server.Properties.AvailabilityZone != zone {
+				return fmt.Errorf("storage.%d: local storage %s is attached to server %s in availability zone %q, it cannot be attached to a server in availability zone %q",
+					idx, storageUUID, serverRel.ObjectUUID, server.Properties.AvailabilityZone, zone)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package gridscale
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/gridscale/gsclient-go/v3"
+)
+
+// maxStorageTemplateUserDataSize is the maximum size (in bytes) of the
+// decoded cloud-init user data of a storage template.
+const maxStorageTemplateUserDataSize = 16384
+
+// storageTemplateWithUserData extends gsclient.StorageTemplate by cloud-init user data,
+// which is not supported by gsclient-go yet.
+type storageTemplateWithUserData struct {
+	gsclient.StorageTemplate
+	// Base64 encoded cloud-init user data.
+	UserData string `json:"user_data"`
+}
+
+// storageCreateRequestWithUserData represents a storage create request
+// whose template contains cloud-init user data.
+type storageCreateRequestWithUserData struct {
+	gsclient.StorageCreateRequest
+	Template *storageTemplateWithUserData `json:"template,omitempty"`
+}
+
+// validateStorageTemplateUserData validates plain text cloud-init user data.
+func validateStorageTemplateUserData(v interface{}, k string) (ws []string, errors []error) {
+	if len(v.(string)) > maxStorageTemplateUserDataSize {
+		errors = append(errors, fmt.Errorf("%s is too large (%d bytes), the maximum size is %d bytes", k, len(v.(string)), maxStorageTemplateUserDataSize))
+	}
+	return
+}
+
+// validateStorageTemplateUserDataBase64 validates base64 encoded cloud-init user data.
+func validateStorageTemplateUserDataBase64(v interface{}, k string) (ws []string, errors []error) {
+	userData, err := base64.StdEncoding.DecodeString(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%s is not a valid base64 string: %v", k, err))
+		return
+	}
+	if len(userData) > maxStorageTemplateUserDataSize {
+		errors = append(errors, fmt.Errorf("%s is too large (%d bytes decoded), the maximum size is %d bytes", k, len(userData), maxStorageTemplateUserDataSize))
+	}
+	return
+}
+
+// createStorageWithUserData creates a storage from a template with cloud-init user data
+// (base64 encoded) and waits until the storage is active.
+// The request is sent directly to the gridscale API, since gsclient-go does not
+// support user data in storage templates yet.
+func createStorageWithUserData(ctx context.Context, client *gsclient.Client, body gsclient.StorageCreateRequest, userData string) (gsclient.CreateResponse, error) {
+	var response gsclient.CreateResponse
+	requestBody := storageCreateRequestWithUserData{
+		StorageCreateRequest: body,
+	}
+	if body.Template != nil {
+		requestBody.Template = &storageTemplateWithUserData{
+			StorageTemplate: *body.Template,
+			UserData:        userData,
+		}
+	}
+	if err := sendAPIRequest(ctx, client, http.MethodPost, "/objects/storages", requestBody, &response); err != nil {
+		return response, err
+	}
+
+	// Wait until the storage is provisioned
+	return response, waitForStorageActive(ctx, client, response.ObjectUUID)
+}